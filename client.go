@@ -0,0 +1,261 @@
+package easygin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client 生成的客户端使用的HTTP客户端
+// 通过client命令生成的客户端嵌入Client，每个API对应一个方法
+type Client struct {
+	BaseURL    string       // 服务地址，如http://127.0.0.1:8080
+	HTTPClient *http.Client // 发送请求使用的http.Client，为nil时使用http.DefaultClient
+	Header     http.Header  // 每个请求都会携带的请求头，如中间件需要的Authorization
+}
+
+// NewClient 创建HTTP客户端
+func NewClient(baseURL string) *Client {
+	return &Client{
+		BaseURL: baseURL,
+		Header:  http.Header{},
+	}
+}
+
+// ClientRequest 描述一次API请求，由生成的客户端方法填充
+type ClientRequest struct {
+	Method      string
+	Path        string // 路由路径，:param格式的路径参数通过SetPathParam替换
+	Query       url.Values
+	Header      http.Header
+	Cookies     []*http.Cookie
+	Body        io.Reader
+	ContentType string
+	Responses   map[int]any // 状态码到响应体的映射，响应体为指针，未声明的2xx响应会丢弃响应体
+}
+
+// NewClientRequest 创建API请求
+func NewClientRequest(method, path string) *ClientRequest {
+	return &ClientRequest{
+		Method: method,
+		Path:   path,
+		Query:  url.Values{},
+		Header: http.Header{},
+	}
+}
+
+// SetPathParam 替换路由路径中的:name或*name参数
+func (r *ClientRequest) SetPathParam(name string, value any) {
+	escaped := url.PathEscape(FormatParameter(value))
+	segments := strings.Split(r.Path, "/")
+	for i, segment := range segments {
+		if segment == ":"+name {
+			segments[i] = escaped
+		} else if segment == "*"+name {
+			// 通配参数包含斜杠，不转义斜杠
+			segments[i] = strings.TrimPrefix(strings.ReplaceAll(escaped, "%2F", "/"), "/")
+		}
+	}
+	r.Path = strings.Join(segments, "/")
+}
+
+// AddCookie 添加Cookie参数
+func (r *ClientRequest) AddCookie(name string, value any) {
+	r.Cookies = append(r.Cookies, &http.Cookie{Name: name, Value: FormatParameter(value)})
+}
+
+// SetJSONBody 将v编码为JSON请求体
+func (r *ClientRequest) SetJSONBody(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode json body failed: %w", err)
+	}
+	r.Body = bytes.NewReader(data)
+	r.ContentType = MIMEJSON
+	return nil
+}
+
+// SetURLEncodedBody 设置application/x-www-form-urlencoded请求体
+func (r *ClientRequest) SetURLEncodedBody(values url.Values) {
+	r.Body = strings.NewReader(values.Encode())
+	r.ContentType = "application/x-www-form-urlencoded"
+}
+
+// SetMultipartBody 设置multipart/form-data请求体
+// 文件字段通过FileHeader.Open读取内容，适用于转发服务端收到的上传文件
+func (r *ClientRequest) SetMultipartBody(values url.Values, files map[string][]*multipart.FileHeader) error {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	for name, vals := range values {
+		for _, val := range vals {
+			if err := writer.WriteField(name, val); err != nil {
+				return err
+			}
+		}
+	}
+	for name, headers := range files {
+		for _, header := range headers {
+			if header == nil {
+				continue
+			}
+			if err := writeMultipartFile(writer, name, header); err != nil {
+				return err
+			}
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	r.Body = &buf
+	r.ContentType = writer.FormDataContentType()
+	return nil
+}
+
+// writeMultipartFile 将文件写入multipart请求体
+func writeMultipartFile(writer *multipart.Writer, name string, header *multipart.FileHeader) error {
+	file, err := header.Open()
+	if err != nil {
+		return fmt.Errorf("open file '%s' failed: %w", header.Filename, err)
+	}
+	defer file.Close()
+
+	part, err := writer.CreateFormFile(name, header.Filename)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(part, file)
+	return err
+}
+
+// Do 发送请求并解码响应
+// 2xx响应解码到Responses中对应状态码的响应体，其他状态码解码为*Error返回
+func (c *Client) Do(ctx context.Context, r *ClientRequest) error {
+	u := strings.TrimSuffix(c.BaseURL, "/") + r.Path
+	if len(r.Query) > 0 {
+		u += "?" + r.Query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.Method, u, r.Body)
+	if err != nil {
+		return err
+	}
+	for key, values := range c.Header {
+		req.Header[key] = append([]string(nil), values...)
+	}
+	for key, values := range r.Header {
+		req.Header[key] = append([]string(nil), values...)
+	}
+	for _, cookie := range r.Cookies {
+		req.AddCookie(cookie)
+	}
+	if r.ContentType != "" {
+		req.Header.Set("Content-Type", r.ContentType)
+	}
+	req.Header.Set("Accept", MIMEJSON)
+	// 将链路追踪信息传递给服务端
+	InjectTraceParent(ctx, req.Header)
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return DecodeErrorResponse(resp)
+	}
+
+	out := r.Responses[resp.StatusCode]
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil && err != io.EOF {
+		return fmt.Errorf("decode response failed: %w", err)
+	}
+	return nil
+}
+
+// DecodeErrorResponse 将非2xx响应解码为*Error
+// 支持JSONErrorRenderer和ProblemErrorRenderer的响应格式，无法解码时使用状态码和响应体构造错误
+func DecodeErrorResponse(resp *http.Response) error {
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return NewError(resp.StatusCode, http.StatusText(resp.StatusCode), err.Error())
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == ContentTypeProblemJSON {
+		var problem Problem
+		if err := json.Unmarshal(data, &problem); err == nil && problem.Title != "" {
+			return NewError(resp.StatusCode, problem.Title, problem.Detail)
+		}
+	}
+
+	e := &Error{}
+	if err := json.Unmarshal(data, e); err == nil && e.M != "" {
+		// 响应体中的code与状态码保持一致
+		e.C = resp.StatusCode
+		return e
+	}
+	return NewError(resp.StatusCode, http.StatusText(resp.StatusCode), string(data))
+}
+
+// IsZeroParameter 判断参数值是否为零值，生成的客户端跳过带omitempty且为零值的参数
+func IsZeroParameter(v any) bool {
+	rv := reflect.ValueOf(v)
+	return !rv.IsValid() || rv.IsZero()
+}
+
+// FormatParameter 将参数值格式化为字符串，格式与参数绑定时的解析方式一致
+// time.Time使用RFC3339格式，指针会解引用，nil指针返回空字符串
+func FormatParameter(v any) string {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return ""
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return ""
+	}
+
+	if t, ok := rv.Interface().(time.Time); ok {
+		return t.Format(time.RFC3339)
+	}
+	if isTimeTypeOrAlias(rv.Type()) {
+		return rv.Convert(reflect.TypeOf(time.Time{})).Interface().(time.Time).Format(time.RFC3339)
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String()
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 64)
+	default:
+		return fmt.Sprint(rv.Interface())
+	}
+}
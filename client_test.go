@@ -0,0 +1,157 @@
+package easygin
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClientDo(t *testing.T) {
+	srv := NewServer("test", ":0", false)
+	root := NewRouterGroup("/")
+	root.RegisterAPI(&TestClientAPI{})
+	srv.setup(root)
+
+	ts := httptest.NewServer(srv.engine)
+	defer ts.Close()
+
+	client := NewClient(ts.URL)
+	client.Header.Set("Token", "secret")
+
+	t.Run("Success", func(t *testing.T) {
+		r := NewClientRequest(http.MethodPost, "/items/:id")
+		r.SetPathParam("id", 7)
+		r.Query.Set("tag", "a")
+		if err := r.SetJSONBody(&TestClientBody{Name: "easygin"}); err != nil {
+			t.Fatalf("SetJSONBody returned error: %v", err)
+		}
+		resp := new(TestClientResp)
+		r.Responses = map[int]any{http.StatusOK: resp}
+
+		if err := client.Do(context.Background(), r); err != nil {
+			t.Fatalf("Do returned error: %v", err)
+		}
+		expected := TestClientResp{ID: 7, Name: "easygin", Tag: "a", Token: "secret"}
+		if *resp != expected {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		r := NewClientRequest(http.MethodPost, "/items/:id")
+		r.SetPathParam("id", 0)
+		_ = r.SetJSONBody(&TestClientBody{Name: "easygin"})
+
+		err := client.Do(context.Background(), r)
+		var e *Error
+		if !errors.As(err, &e) || e.C != http.StatusNotFound || e.M != "item not found" {
+			t.Fatalf("expected decoded *Error, got %#v", err)
+		}
+	})
+
+	t.Run("ProblemError", func(t *testing.T) {
+		srv := NewServer("test", ":0", false).WithErrorRenderer(NewProblemErrorRenderer())
+		root := NewRouterGroup("/")
+		root.RegisterAPI(&TestClientAPI{})
+		srv.setup(root)
+		ts := httptest.NewServer(srv.engine)
+		defer ts.Close()
+
+		r := NewClientRequest(http.MethodPost, "/items/:id")
+		r.SetPathParam("id", 0)
+		_ = r.SetJSONBody(&TestClientBody{Name: "easygin"})
+
+		err := NewClient(ts.URL).Do(context.Background(), r)
+		var e *Error
+		if !errors.As(err, &e) || e.C != http.StatusNotFound || e.M != "item not found" {
+			t.Fatalf("expected decoded problem error, got %#v", err)
+		}
+	})
+}
+
+func TestFormatParameter(t *testing.T) {
+	name := "easygin"
+	var nilPtr *int
+	cases := []struct {
+		value    any
+		expected string
+	}{
+		{"text", "text"},
+		{42, "42"},
+		{uint8(8), "8"},
+		{1.5, "1.5"},
+		{true, "true"},
+		{&name, "easygin"},
+		{nilPtr, ""},
+		{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), "2024-01-02T03:04:05Z"},
+	}
+	for _, c := range cases {
+		if got := FormatParameter(c.value); got != c.expected {
+			t.Errorf("FormatParameter(%#v) = %q, expected %q", c.value, got, c.expected)
+		}
+	}
+}
+
+func TestDecodeProblemErrorResponse(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusNotFound,
+		Header:     http.Header{"Content-Type": []string{ContentTypeProblemJSON}},
+		Body:       io.NopCloser(strings.NewReader(`{"type":"about:blank","title":"user not found","status":404,"detail":"user 1 does not exist"}`)),
+	}
+	var e *Error
+	if err := DecodeErrorResponse(resp); !errors.As(err, &e) || e.C != http.StatusNotFound || e.M != "user not found" || e.D != "user 1 does not exist" {
+		t.Fatalf("expected title as message and detail as desc, got %#v", err)
+	}
+}
+
+func TestSetPathParam(t *testing.T) {
+	r := NewClientRequest(http.MethodGet, "/files/:id/*path")
+	r.SetPathParam("id", "a b")
+	r.SetPathParam("path", "/dir/file.txt")
+	if r.Path != "/files/a%20b/dir/file.txt" {
+		t.Fatalf("unexpected path: %s", r.Path)
+	}
+}
+
+// TestClientAPI 用于测试客户端请求和代码生成
+type TestClientAPI struct {
+	MethodPost `summary:"更新条目"`
+	ID         int             `in:"path" name:"id"`
+	Tag        string          `in:"query" name:"tag,omitempty"`
+	Token      string          `in:"header" name:"Token,omitempty"`
+	Body       *TestClientBody `in:"body"`
+}
+
+type TestClientBody struct {
+	Name string `json:"name"`
+}
+
+type TestClientResp struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Tag   string `json:"tag"`
+	Token string `json:"token"`
+}
+
+func (TestClientAPI) Path() string {
+	return "/items/:id"
+}
+
+func (TestClientAPI) Responses() R {
+	return R{
+		http.StatusOK:       &TestClientResp{},
+		http.StatusNotFound: &Error{},
+	}
+}
+
+func (api *TestClientAPI) Output(ctx context.Context) (any, error) {
+	if api.ID == 0 {
+		return nil, NewError(http.StatusNotFound, "item not found", "")
+	}
+	return &TestClientResp{ID: api.ID, Name: api.Body.Name, Tag: api.Tag, Token: api.Token}, nil
+}
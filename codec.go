@@ -0,0 +1,430 @@
+package easygin
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ugorji/go/codec"
+	"google.golang.org/protobuf/proto"
+)
+
+// Codec 定义了请求体和响应体的编解码方式
+// 请求体按照Content-Type选择Codec解码，响应体按照Accept选择Codec编码
+type Codec interface {
+	ContentType() string             // 返回媒体类型，如application/json，可以携带charset等参数
+	Decode(r io.Reader, v any) error // 将请求体解码到v
+	Encode(w io.Writer, v any) error // 将v编码到响应体
+}
+
+// TypedCodec 只支持部分类型的Codec可以实现该接口
+// 内容协商和生成OpenAPI文档时会跳过不支持的类型，例如ProtobufCodec只支持proto.Message
+type TypedCodec interface {
+	Supports(t reflect.Type) bool
+}
+
+// 内置Codec的媒体类型
+const (
+	MIMEJSON     = "application/json"
+	MIMEXML      = "application/xml"
+	MIMEMsgPack  = "application/msgpack"
+	MIMEProtobuf = "application/x-protobuf"
+)
+
+var (
+	codecMu sync.RWMutex
+	// codecs 按照注册顺序保存的Codec，未指定mime标签时只使用JSON
+	codecs []Codec
+	// codecAliases mime标签中可以使用的简称
+	codecAliases = map[string]string{
+		"json":     MIMEJSON,
+		"xml":      MIMEXML,
+		"msgpack":  MIMEMsgPack,
+		"protobuf": MIMEProtobuf,
+	}
+)
+
+func init() {
+	RegisterCodec(JSONCodec{})
+	RegisterCodec(XMLCodec{})
+	RegisterCodec(MsgPackCodec{})
+	RegisterCodec(ProtobufCodec{})
+}
+
+// RegisterCodec 注册Codec，媒体类型相同的Codec会被替换
+// 需要在注册路由之前调用
+func RegisterCodec(c Codec) {
+	codecMu.Lock()
+	defer codecMu.Unlock()
+
+	mediaType := codecMediaType(c)
+	for i := range codecs {
+		if codecMediaType(codecs[i]) == mediaType {
+			codecs[i] = c
+			return
+		}
+	}
+	codecs = append(codecs, c)
+}
+
+// LookupCodec 根据媒体类型或简称获取已注册的Codec
+func LookupCodec(name string) (Codec, bool) {
+	codecMu.RLock()
+	defer codecMu.RUnlock()
+
+	mediaType := strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := codecAliases[mediaType]; ok {
+		mediaType = alias
+	}
+	for _, c := range codecs {
+		if codecMediaType(c) == mediaType {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// RequestCodec 根据请求的Content-Type选择将请求体解码到v的Codec
+// allowed为mime标签中声明的媒体类型或简称，为空时不检查Content-Type，始终使用JSON解码
+// 请求未携带Content-Type时使用允许的第一个Codec，不支持的Content-Type返回415错误
+func RequestCodec(c *gin.Context, v any, allowed ...string) (Codec, error) {
+	if len(allowed) == 0 {
+		return defaultCodec()
+	}
+	candidates, err := supportedCodecs(allowed, reflect.TypeOf(v))
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := strings.ToLower(c.ContentType())
+	if contentType == "" && len(candidates) > 0 {
+		return candidates[0], nil
+	}
+	for _, candidate := range candidates {
+		if codecMediaType(candidate) == contentType {
+			return candidate, nil
+		}
+	}
+	return nil, NewError(http.StatusUnsupportedMediaType, "unsupported media type", fmt.Sprintf("content type '%s' is not supported, expected one of [%s]", contentType, strings.Join(codecMediaTypes(candidates), ", ")))
+}
+
+// ResponseCodec 根据请求的Accept选择编码响应体v的Codec
+// allowed为空时不检查Accept，始终使用JSON编码
+// 未携带Accept或者接受任意类型时使用允许的第一个Codec，没有可接受的Codec返回406错误
+func ResponseCodec(c *gin.Context, v any, allowed ...string) (Codec, error) {
+	if len(allowed) == 0 {
+		return defaultCodec()
+	}
+	candidates, err := supportedCodecs(allowed, reflect.TypeOf(v))
+	if err != nil {
+		return nil, err
+	}
+	return negotiateCodec(c.GetHeader("Accept"), candidates)
+}
+
+// negotiateCodec 按照Accept中的q值从高到低匹配Codec，q值相同时保持Accept中的顺序
+func negotiateCodec(accept string, candidates []Codec) (Codec, error) {
+	if strings.TrimSpace(accept) == "" && len(candidates) > 0 {
+		return candidates[0], nil
+	}
+
+	type acceptRange struct {
+		mediaType string
+		q         float64
+	}
+	ranges := make([]acceptRange, 0)
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		ranges = append(ranges, acceptRange{mediaType: mediaType, q: q})
+	}
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].q > ranges[j].q
+	})
+
+	for _, r := range ranges {
+		for _, candidate := range candidates {
+			if mediaTypeMatches(r.mediaType, codecMediaType(candidate)) {
+				return candidate, nil
+			}
+		}
+	}
+	return nil, NewError(http.StatusNotAcceptable, "not acceptable", fmt.Sprintf("accept '%s' is not supported, expected one of [%s]", accept, strings.Join(codecMediaTypes(candidates), ", ")))
+}
+
+// mediaTypeMatches 判断Accept中的媒体范围是否匹配媒体类型，支持*/*和type/*
+func mediaTypeMatches(mediaRange, mediaType string) bool {
+	if mediaRange == "*/*" || mediaRange == mediaType {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(mediaRange, "/*"); ok {
+		return strings.HasPrefix(mediaType, prefix+"/")
+	}
+	return false
+}
+
+// defaultCodec 获取未指定mime标签时使用的JSON Codec
+// XML、MsgPack和Protobuf等其他Codec需要在mime标签中声明
+func defaultCodec() (Codec, error) {
+	c, ok := LookupCodec(MIMEJSON)
+	if !ok {
+		return nil, fmt.Errorf("codec '%s' is not registered", MIMEJSON)
+	}
+	return c, nil
+}
+
+// resolveCodecs 将mime标签中声明的媒体类型或简称解析为Codec列表，未声明时只包含JSON
+func resolveCodecs(allowed []string) ([]Codec, error) {
+	if len(allowed) == 0 {
+		c, err := defaultCodec()
+		if err != nil {
+			return nil, err
+		}
+		return []Codec{c}, nil
+	}
+
+	resolved := make([]Codec, 0, len(allowed))
+	for _, name := range allowed {
+		c, ok := LookupCodec(name)
+		if !ok {
+			return nil, fmt.Errorf("codec '%s' is not registered", name)
+		}
+		resolved = append(resolved, c)
+	}
+	return resolved, nil
+}
+
+// supportedCodecs 获取mime标签中声明的Codec中支持类型t的Codec
+func supportedCodecs(allowed []string, t reflect.Type) ([]Codec, error) {
+	candidates, err := resolveCodecs(allowed)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return candidates, nil
+	}
+	supported := make([]Codec, 0, len(candidates))
+	for _, c := range candidates {
+		if typed, ok := c.(TypedCodec); ok && !typed.Supports(t) {
+			continue
+		}
+		supported = append(supported, c)
+	}
+	return supported, nil
+}
+
+// validateCodecTags 校验API中Method字段和body字段上mime标签声明的Codec是否已注册
+func validateCodecTags(t reflect.Type) error {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if isMethodField(field) || field.Tag.Get("in") == "body" {
+			if _, err := resolveCodecs(parseCodecTag(field.Tag.Get("mime"))); err != nil {
+				return fmt.Errorf("field %s: %w", field.Name, err)
+			}
+			continue
+		}
+		if field.Anonymous && field.Tag.Get("in") == "" {
+			if err := validateCodecTags(field.Type); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// parseCodecTag 解析mime标签中声明的Codec，multipart和urlencoded表单不属于Codec
+func parseCodecTag(tag string) []string {
+	if tag == "" || tag == "multipart" || tag == "urlencoded" {
+		return nil
+	}
+	names := make([]string, 0)
+	for _, name := range strings.Split(tag, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// methodCodecTag 获取API上Method字段声明的mime标签，用于限制响应体的Codec
+func methodCodecTag(h RouterHandler) string {
+	tag, _ := getMethodFieldTag(h, "mime")
+	return tag
+}
+
+// codecMediaType 获取Codec去掉参数后的媒体类型
+func codecMediaType(c Codec) string {
+	mediaType, _, err := mime.ParseMediaType(c.ContentType())
+	if err != nil {
+		return strings.ToLower(c.ContentType())
+	}
+	return mediaType
+}
+
+// codecName 获取Codec的简称，用于错误信息，如application/x-protobuf的简称为protobuf
+func codecName(c Codec) string {
+	mediaType := codecMediaType(c)
+	if _, subtype, ok := strings.Cut(mediaType, "/"); ok {
+		return strings.TrimPrefix(subtype, "x-")
+	}
+	return mediaType
+}
+
+// codecMediaTypes 获取Codec列表对应的媒体类型
+func codecMediaTypes(list []Codec) []string {
+	mediaTypes := make([]string, 0, len(list))
+	for _, c := range list {
+		mediaTypes = append(mediaTypes, codecMediaType(c))
+	}
+	return mediaTypes
+}
+
+// codecRender 使用Codec渲染响应体
+type codecRender struct {
+	codec Codec
+	data  any
+}
+
+func (r codecRender) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	return r.codec.Encode(w, r.data)
+}
+
+func (r codecRender) WriteContentType(w http.ResponseWriter) {
+	header := w.Header()
+	if val := header["Content-Type"]; len(val) == 0 {
+		header["Content-Type"] = []string{r.codec.ContentType()}
+	}
+}
+
+// JSONCodec 使用encoding/json编解码
+type JSONCodec struct{}
+
+func (JSONCodec) ContentType() string {
+	return "application/json; charset=utf-8"
+}
+
+func (JSONCodec) Decode(r io.Reader, v any) error {
+	return json.NewDecoder(r).Decode(v)
+}
+
+func (JSONCodec) Encode(w io.Writer, v any) error {
+	// 与gin的c.JSON保持一致，不追加换行符
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// XMLCodec 使用encoding/xml编解码
+type XMLCodec struct{}
+
+func (XMLCodec) ContentType() string {
+	return "application/xml; charset=utf-8"
+}
+
+// Supports encoding/xml不支持map类型
+func (XMLCodec) Supports(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() != reflect.Map
+}
+
+func (XMLCodec) Decode(r io.Reader, v any) error {
+	return xml.NewDecoder(r).Decode(v)
+}
+
+func (XMLCodec) Encode(w io.Writer, v any) error {
+	return xml.NewEncoder(w).Encode(v)
+}
+
+// MsgPackCodec 使用MessagePack编解码，字段名取自json标签
+type MsgPackCodec struct{}
+
+var msgpackHandle = func() *codec.MsgpackHandle {
+	// 默认按照codec和json标签获取字段名
+	h := &codec.MsgpackHandle{}
+	h.WriteExt = true
+	h.RawToString = true
+	return h
+}()
+
+func (MsgPackCodec) ContentType() string {
+	return MIMEMsgPack
+}
+
+func (MsgPackCodec) Decode(r io.Reader, v any) error {
+	return codec.NewDecoder(r, msgpackHandle).Decode(v)
+}
+
+func (MsgPackCodec) Encode(w io.Writer, v any) error {
+	return codec.NewEncoder(w, msgpackHandle).Encode(v)
+}
+
+// ProtobufCodec 使用Protocol Buffers编解码，请求体和响应体必须实现proto.Message
+type ProtobufCodec struct{}
+
+var protoMessageType = reflect.TypeOf((*proto.Message)(nil)).Elem()
+
+// Supports 只支持实现了proto.Message的类型
+func (ProtobufCodec) Supports(t reflect.Type) bool {
+	return t.Implements(protoMessageType)
+}
+
+func (ProtobufCodec) ContentType() string {
+	return MIMEProtobuf
+}
+
+func (ProtobufCodec) Decode(r io.Reader, v any) error {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("%T does not implement proto.Message", v)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(data, msg)
+}
+
+func (ProtobufCodec) Encode(w io.Writer, v any) error {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("%T does not implement proto.Message", v)
+	}
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
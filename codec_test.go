@@ -0,0 +1,301 @@
+package easygin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ugorji/go/codec"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestNegotiateCodec(t *testing.T) {
+	candidates, err := resolveCodecs([]string{"json", "xml", "msgpack", "protobuf"})
+	if err != nil {
+		t.Fatalf("resolveCodecs returned error: %v", err)
+	}
+
+	cases := []struct {
+		accept    string
+		mediaType string
+	}{
+		{"", MIMEJSON},
+		{"*/*", MIMEJSON},
+		{"application/xml", MIMEXML},
+		{"text/html, application/xml;q=0.9, application/json;q=0.8", MIMEXML},
+		{"application/json;q=0.5, application/msgpack", MIMEMsgPack},
+		{"application/*;q=0.1, application/x-protobuf;q=0", MIMEJSON},
+	}
+	for _, c := range cases {
+		selected, err := negotiateCodec(c.accept, candidates)
+		if err != nil {
+			t.Fatalf("negotiateCodec(%q) returned error: %v", c.accept, err)
+		}
+		if mediaType := codecMediaType(selected); mediaType != c.mediaType {
+			t.Fatalf("negotiateCodec(%q) = %s, expected %s", c.accept, mediaType, c.mediaType)
+		}
+	}
+
+	_, err = negotiateCodec("text/html", candidates)
+	if errorHttp, ok := err.(ErrorHttp); !ok || errorHttp.StatusCode() != http.StatusNotAcceptable {
+		t.Fatalf("expected 406 error, got %v", err)
+	}
+}
+
+func TestSupportedCodecs(t *testing.T) {
+	codecs, err := supportedCodecs(nil, reflect.TypeOf(&TestCodecBody{}))
+	if err != nil {
+		t.Fatalf("supportedCodecs returned error: %v", err)
+	}
+	if mediaTypes := codecMediaTypes(codecs); !reflect.DeepEqual(mediaTypes, []string{MIMEJSON}) {
+		t.Fatalf("expected only json without mime tag, got %v", mediaTypes)
+	}
+
+	codecs, err = supportedCodecs([]string{"json", "xml", "msgpack", "protobuf"}, reflect.TypeOf(&TestCodecBody{}))
+	if err != nil {
+		t.Fatalf("supportedCodecs returned error: %v", err)
+	}
+	if mediaTypes := codecMediaTypes(codecs); !reflect.DeepEqual(mediaTypes, []string{MIMEJSON, MIMEXML, MIMEMsgPack}) {
+		t.Fatalf("expected protobuf to be skipped for plain struct, got %v", mediaTypes)
+	}
+
+	codecs, err = supportedCodecs([]string{"protobuf", "json"}, reflect.TypeOf(&wrapperspb.StringValue{}))
+	if err != nil {
+		t.Fatalf("supportedCodecs returned error: %v", err)
+	}
+	if mediaTypes := codecMediaTypes(codecs); !reflect.DeepEqual(mediaTypes, []string{MIMEProtobuf, MIMEJSON}) {
+		t.Fatalf("expected mime tag order to be kept, got %v", mediaTypes)
+	}
+
+	if _, err := supportedCodecs([]string{"yaml"}, nil); err == nil {
+		t.Fatal("expected unregistered codec to fail")
+	}
+	if err := validateCodecTags(reflect.TypeOf(&TestUnknownCodecHandler{})); err == nil {
+		t.Fatal("expected unknown codec in mime tag to fail validation")
+	}
+	// Method字段按照Method()接口识别，与类型名称无关
+	if err := validateCodecTags(reflect.TypeOf(&TestCustomMethodCodecHandler{})); err == nil {
+		t.Fatal("expected unknown codec in custom method mime tag to fail validation")
+	}
+	if tag := methodCodecTag(&TestCustomMethodCodecHandler{}); tag != "yaml" {
+		t.Fatalf("expected mime tag of custom method field, got %q", tag)
+	}
+}
+
+func TestCodecBinding(t *testing.T) {
+	body := TestCodecBody{Name: "easygin", Count: 3}
+
+	t.Run("XML", func(t *testing.T) {
+		data, _ := xml.Marshal(body)
+		req := httptest.NewRequest(http.MethodPost, "/codec", bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+
+		bound := bindHandlerForTest(t, &TestCodecHandler{}, req, nil).(*TestCodecHandler)
+		if bound.Body.Name != body.Name || bound.Body.Count != body.Count {
+			t.Fatalf("expected xml body to be bound, got %+v", bound.Body)
+		}
+	})
+
+	t.Run("MsgPack", func(t *testing.T) {
+		var data []byte
+		if err := codec.NewEncoderBytes(&data, msgpackHandle).Encode(body); err != nil {
+			t.Fatalf("encode msgpack failed: %v", err)
+		}
+		req := httptest.NewRequest(http.MethodPost, "/codec", bytes.NewReader(data))
+		req.Header.Set("Content-Type", MIMEMsgPack)
+
+		bound := bindHandlerForTest(t, &TestCodecHandler{}, req, nil).(*TestCodecHandler)
+		if bound.Body.Name != body.Name || bound.Body.Count != body.Count {
+			t.Fatalf("expected msgpack body to be bound, got %+v", bound.Body)
+		}
+	})
+
+	t.Run("UnsupportedMediaType", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/codec", bytes.NewReader([]byte("{}")))
+		req.Header.Set("Content-Type", MIMEProtobuf)
+		recorder := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(recorder)
+		ctx.Request = req
+
+		_, err := bindParams(ctx, &TestCodecHandler{})
+		if errorHttp, ok := err.(ErrorHttp); !ok || errorHttp.StatusCode() != http.StatusUnsupportedMediaType {
+			t.Fatalf("expected 415 error, got %v", err)
+		}
+	})
+
+	t.Run("UnsupportedMediaTypeResponse", func(t *testing.T) {
+		// 经过renderAPI处理，反射绑定和静态绑定方法返回的415不能被改写为400
+		gin.SetMode(gin.TestMode)
+		engine := gin.New()
+		engine.POST("/codec", renderAPI(&TestCodecHandler{}, "TestCodecHandler"))
+		engine.POST("/generated", renderAPI(&TestGeneratedCodecHandler{}, "TestGeneratedCodecHandler"))
+
+		for _, path := range []string{"/codec", "/generated"} {
+			req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader([]byte("name,count")))
+			req.Header.Set("Content-Type", "text/csv")
+			recorder := httptest.NewRecorder()
+			engine.ServeHTTP(recorder, req)
+			if recorder.Code != http.StatusUnsupportedMediaType {
+				t.Fatalf("%s: expected 415, got %d %s", path, recorder.Code, recorder.Body.String())
+			}
+		}
+	})
+
+	t.Run("DefaultJSON", func(t *testing.T) {
+		// 未声明mime标签时忽略Content-Type，与curl -d默认的urlencoded请求兼容
+		req := httptest.NewRequest(http.MethodPost, "/codec", bytes.NewReader([]byte(`{"name":"easygin","count":3}`)))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		bound := bindHandlerForTest(t, &TestDefaultCodecHandler{}, req, nil).(*TestDefaultCodecHandler)
+		if bound.Body.Name != body.Name || bound.Body.Count != body.Count {
+			t.Fatalf("expected json body to be bound, got %+v", bound.Body)
+		}
+	})
+}
+
+func TestCodecRender(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.POST("/codec", renderAPI(&TestCodecHandler{}, "TestCodecHandler"))
+	engine.GET("/proto", renderAPI(&TestProtoHandler{}, "TestProtoHandler"))
+	engine.POST("/default", renderAPI(&TestDefaultCodecHandler{}, "TestDefaultCodecHandler"))
+
+	request := func(method, path, accept string, body []byte) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", MIMEJSON)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		recorder := httptest.NewRecorder()
+		engine.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	data, _ := json.Marshal(TestCodecBody{Name: "easygin", Count: 3})
+
+	recorder := request(http.MethodPost, "/codec", "", data)
+	if ct := recorder.Header().Get("Content-Type"); ct != "application/json; charset=utf-8" || recorder.Body.String() != string(data) {
+		t.Fatalf("expected json response, got %s %s", ct, recorder.Body.String())
+	}
+
+	recorder = request(http.MethodPost, "/codec", "application/xml", data)
+	var xmlBody TestCodecBody
+	if err := xml.Unmarshal(recorder.Body.Bytes(), &xmlBody); err != nil || xmlBody.Name != "easygin" {
+		t.Fatalf("expected xml response, got %s (%v)", recorder.Body.String(), err)
+	}
+
+	// Method字段上的mime标签限制响应只能使用json和xml
+	recorder = request(http.MethodPost, "/codec", MIMEMsgPack, data)
+	if recorder.Code != http.StatusNotAcceptable {
+		t.Fatalf("expected 406 for msgpack response, got %d", recorder.Code)
+	}
+
+	// 未声明mime标签时浏览器的Accept也返回JSON
+	recorder = request(http.MethodPost, "/default", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", data)
+	if ct := recorder.Header().Get("Content-Type"); recorder.Code != http.StatusOK || ct != "application/json; charset=utf-8" {
+		t.Fatalf("expected json response without mime tag, got %d %s", recorder.Code, ct)
+	}
+
+	recorder = request(http.MethodGet, "/proto", MIMEProtobuf, nil)
+	var msg wrapperspb.StringValue
+	if err := proto.Unmarshal(recorder.Body.Bytes(), &msg); err != nil || msg.GetValue() != "easygin" {
+		t.Fatalf("expected protobuf response, got %q (%v)", recorder.Body.String(), err)
+	}
+}
+
+type TestCodecBody struct {
+	XMLName xml.Name `json:"-" xml:"body"`
+	Name    string   `json:"name" xml:"name"`
+	Count   int      `json:"count" xml:"count"`
+}
+
+type TestCodecHandler struct {
+	MethodPost `mime:"json,xml"`
+	Body       TestCodecBody `in:"body" mime:"json,xml,msgpack,protobuf"`
+}
+
+func (h *TestCodecHandler) Path() string {
+	return "/codec"
+}
+
+func (h *TestCodecHandler) Output(ctx context.Context) (any, error) {
+	return h.Body, nil
+}
+
+type TestProtoHandler struct {
+	MethodGet `mime:"json,protobuf"`
+}
+
+func (h *TestProtoHandler) Path() string {
+	return "/proto"
+}
+
+func (h *TestProtoHandler) Output(ctx context.Context) (any, error) {
+	return wrapperspb.String("easygin"), nil
+}
+
+// TestGeneratedCodecHandler 模拟静态参数绑定方法中的请求体绑定
+type TestGeneratedCodecHandler struct {
+	MethodPost
+	Body TestCodecBody `in:"body" mime:"json,xml"`
+}
+
+func (h *TestGeneratedCodecHandler) Path() string {
+	return "/generated"
+}
+
+func (h *TestGeneratedCodecHandler) EasyGinBindParameters(c *gin.Context) error {
+	codec, err := RequestCodec(c, &h.Body, "json", "xml")
+	if err != nil {
+		return err
+	}
+	return codec.Decode(c.Request.Body, &h.Body)
+}
+
+func (h *TestGeneratedCodecHandler) Output(ctx context.Context) (any, error) {
+	return h.Body, nil
+}
+
+type TestDefaultCodecHandler struct {
+	MethodPost
+	Body TestCodecBody `in:"body"`
+}
+
+func (h *TestDefaultCodecHandler) Path() string {
+	return "/default"
+}
+
+func (h *TestDefaultCodecHandler) Output(ctx context.Context) (any, error) {
+	return h.Body, nil
+}
+
+type TestUnknownCodecHandler struct {
+	MethodPost
+	Body TestCodecBody `in:"body" mime:"yaml"`
+}
+
+// TestCustomPost 名称不以Method开头的Method字段类型
+type TestCustomPost struct{}
+
+func (TestCustomPost) Method() string {
+	return http.MethodPost
+}
+
+type TestCustomMethodCodecHandler struct {
+	TestCustomPost `mime:"yaml"`
+}
+
+func (TestCustomMethodCodecHandler) Path() string {
+	return "/custom"
+}
+
+func (TestCustomMethodCodecHandler) Output(ctx context.Context) (any, error) {
+	return nil, nil
+}
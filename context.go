@@ -52,3 +52,52 @@ func RouteFromContext(ctx context.Context) RouterAPI {
 	}
 	return raw.(RouterAPI)
 }
+
+// ContextWithMiddlewareOutput 将中间件的输出以ContextKey()为键存储到上下文中，与中间件执行后的上下文一致
+// 单元测试API的Output时可以用来注入伪造的中间件输出
+func ContextWithMiddlewareOutput(ctx context.Context, middleware ContextKey, output any) context.Context {
+	return context.WithValue(ctx, middleware.ContextKey(), output)
+}
+
+// FromContext 从上下文中获取中间件的输出，中间件未执行或输出的类型不是T时ok为false
+//
+//	user, ok := easygin.FromContext[*UserInfo](ctx, &MustAuth{})
+func FromContext[T any](ctx context.Context, middleware ContextKey) (T, bool) {
+	output, ok := ctx.Value(middleware.ContextKey()).(T)
+	return output, ok
+}
+
+// ContextWithErrorRenderer 将 ErrorRenderer 存储到上下文中
+func ContextWithErrorRenderer(ctx context.Context, renderer ErrorRenderer) context.Context {
+	return context.WithValue(ctx, contextKey(3), renderer)
+}
+
+// ErrorRendererFromContext 从上下文中获取 ErrorRenderer，不存在时返回默认的JSONErrorRenderer
+func ErrorRendererFromContext(ctx context.Context) ErrorRenderer {
+	if renderer, ok := ctx.Value(contextKey(3)).(ErrorRenderer); ok {
+		return renderer
+	}
+	return JSONErrorRenderer{}
+}
+
+// contextWithEventStreamStats 将事件流的输出统计存储到上下文中，用于访问日志
+func contextWithEventStreamStats(ctx context.Context, stats *eventStreamStats) context.Context {
+	return context.WithValue(ctx, contextKey(4), stats)
+}
+
+// eventStreamStatsFromContext 从上下文中获取事件流的输出统计，非事件流响应返回nil
+func eventStreamStatsFromContext(ctx context.Context) *eventStreamStats {
+	stats, _ := ctx.Value(contextKey(4)).(*eventStreamStats)
+	return stats
+}
+
+// contextWithOpenAPIDocument 将启动时生成的OpenAPI文档存储到上下文中
+func contextWithOpenAPIDocument(ctx context.Context, document *openAPIDocument) context.Context {
+	return context.WithValue(ctx, contextKey(5), document)
+}
+
+// openAPIDocumentFromContext 从上下文中获取启动时生成的OpenAPI文档，未启用时返回nil
+func openAPIDocumentFromContext(ctx context.Context) *openAPIDocument {
+	document, _ := ctx.Value(contextKey(5)).(*openAPIDocument)
+	return document
+}
@@ -1,10 +1,10 @@
 package easygin
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net/url"
 	"reflect"
 	"slices"
 	"strings"
@@ -21,20 +21,27 @@ type bodyFieldInfo struct {
 	defaultValue string
 }
 
-// decodeJSON 从请求中解析JSON数据并验证必填字段
-func decodeJSON(r io.Reader, v any) error {
-	// 使用json.NewDecoder避免额外的内存分配
-	decoder := json.NewDecoder(r)
-	if err := decoder.Decode(v); err != nil {
-		return fmt.Errorf("parse json failed: %v", err)
+// decodeBody 使用Codec解析请求体并验证必填字段
+func decodeBody(codec Codec, r io.Reader, v any) error {
+	if err := codec.Decode(r, v); err != nil {
+		return fmt.Errorf("parse %s failed: %v", codecName(codec), err)
 	}
 
-	if !HandleBodyJsonOmitEmptyAndDefault() {
-		return nil
+	// 收集所有字段的校验错误
+	verrs := &ValidationError{}
+
+	if HandleBodyJsonOmitEmptyAndDefault() {
+		// 验证必填字段
+		if err := verrs.Collect(ValidateJsonRequiredFields(reflect.ValueOf(v))); err != nil {
+			return err
+		}
 	}
 
-	// 验证必填字段
-	return ValidateJsonRequiredFields(reflect.ValueOf(v))
+	// 按照validate标签校验字段
+	if err := ValidateJsonFields(reflect.ValueOf(v), verrs); err != nil {
+		return err
+	}
+	return verrs.Err()
 }
 
 // handleEmptyValue 处理字段值为空的情况，检查omitempty和default标签
@@ -133,8 +140,18 @@ func getValidateFields(t reflect.Type) []validateFieldInfo {
 	return fields
 }
 
-// validateRequiredFields 递归验证结构体的必填字段
+// ValidateJsonRequiredFields 递归验证结构体的必填字段
+// 缺失的必填字段以*ValidationError返回，嵌套字段名使用.连接
 func ValidateJsonRequiredFields(v reflect.Value) error {
+	verrs := &ValidationError{}
+	if err := validateJsonRequiredFields(v, "", verrs); err != nil {
+		return err
+	}
+	return verrs.Err()
+}
+
+// validateJsonRequiredFields 递归验证结构体的必填字段，缺失的字段收集到verrs中
+func validateJsonRequiredFields(v reflect.Value, prefix string, verrs *ValidationError) error {
 	// 处理指针类型，添加安全检查
 	for {
 		if v.Kind() == reflect.Ptr {
@@ -159,15 +176,19 @@ func ValidateJsonRequiredFields(v reflect.Value) error {
 	// 遍历字段信息
 	for _, info := range fields {
 		fieldValue := v.Field(info.index)
+		name := info.jsonName
+		if prefix != "" {
+			name = prefix + "." + name
+		}
 
 		// 处理结构体类型字段（包括指针类型的结构体）
 		if info.isStruct {
 			// 如果是指针类型
 			if fieldValue.Kind() == reflect.Ptr {
-				// 如果指针为nil且字段不能为空，返回错误
+				// 如果指针为nil且字段不能为空，记录错误
 				if fieldValue.IsNil() {
 					if !info.canBeEmpty {
-						return fmt.Errorf("missing required field '%s' in body", info.jsonName)
+						verrs.Add("body", name, reasonMissingField, "")
 					}
 					// 如果字段可以为空，则跳过后续验证
 					continue
@@ -180,7 +201,7 @@ func ValidateJsonRequiredFields(v reflect.Value) error {
 				}
 			}
 			// 无论字段是否可以为空，只要不为nil就需要递归验证其内部字段
-			if err := ValidateJsonRequiredFields(fieldValue); err != nil {
+			if err := validateJsonRequiredFields(fieldValue, name, verrs); err != nil {
 				return err
 			}
 			continue
@@ -189,7 +210,8 @@ func ValidateJsonRequiredFields(v reflect.Value) error {
 		// 如果字段值为空，检查omitempty和default标签
 		if isEmptyValue(fieldValue) {
 			if !info.canBeEmpty {
-				return fmt.Errorf("missing required field '%s' in body", info.jsonName)
+				verrs.Add("body", name, reasonMissingField, "")
+				continue
 			}
 			// 如果有默认值，设置默认值
 			if info.defaultValue != "" {
@@ -203,12 +225,13 @@ func ValidateJsonRequiredFields(v reflect.Value) error {
 	return nil
 }
 
-// handleFormFieldValue 统一处理表单字段值的验证和设置
-func handleFormFieldValue(field reflect.StructField, fieldVal reflect.Value, value, name, structPath string) error {
+// handleFormFieldValue 统一处理表单字段值的验证和设置，字段错误收集到verrs中
+func handleFormFieldValue(field reflect.StructField, fieldVal reflect.Value, value, name, structPath string, verrs *ValidationError) error {
 	// 如果值不为空，先尝试设置字段值
 	if value != "" {
 		if err := setFieldValue(fieldVal, value, name, field.Type); err != nil {
-			return err
+			verrs.Add("form", name, reasonInvalidValue, value)
+			return nil
 		}
 	}
 
@@ -216,7 +239,8 @@ func handleFormFieldValue(field reflect.StructField, fieldVal reflect.Value, val
 	if value == "" || isEmptyValue(fieldVal) {
 		canBeEmpty, defaultValue := handleEmptyValue(structPath, field, "name")
 		if !canBeEmpty {
-			return fmt.Errorf("missing required parameter '%s' in form", name)
+			verrs.Add("form", name, reasonMissingParameter, value)
+			return nil
 		}
 		// 如果有默认值，设置默认值
 		if defaultValue != "" {
@@ -228,12 +252,12 @@ func handleFormFieldValue(field reflect.StructField, fieldVal reflect.Value, val
 	return nil
 }
 
-// handleSliceValue 处理数组类型字段的值
-func handleSliceValue(fieldVal reflect.Value, vals []string, name string) error {
+// handleSliceValue 处理数组类型字段的值，元素转换失败时记录字段错误
+func handleSliceValue(fieldVal reflect.Value, vals []string, name string, verrs *ValidationError) {
 	// 如果没有值且字段类型是字符串数组，直接返回空数组
 	if len(vals) == 0 && fieldVal.Type().Elem().Kind() == reflect.String {
 		fieldVal.Set(reflect.MakeSlice(fieldVal.Type(), 0, 0))
-		return nil
+		return
 	}
 
 	// 创建新的切片
@@ -246,13 +270,13 @@ func handleSliceValue(fieldVal reflect.Value, vals []string, name string) error
 
 		// 设置元素值
 		if err := setFieldValue(elem, val, name, elem.Type()); err != nil {
-			return err
+			verrs.Add("form", name, reasonInvalidValue, strings.Join(vals, ","))
+			return
 		}
 	}
 
 	// 设置字段值
 	fieldVal.Set(slice)
-	return nil
 }
 
 // formFieldCache 用于缓存结构体字段的表单信息
@@ -266,6 +290,7 @@ type formFieldInfo struct {
 	fieldIndex int
 	fieldType  reflect.Type
 	structPath string
+	validate   string
 }
 
 // getFormFields 获取并缓存结构体的表单字段信息
@@ -312,6 +337,7 @@ func getFormFields(t reflect.Type) []formFieldInfo {
 			fieldIndex: i,
 			fieldType:  fieldType,
 			structPath: structPath,
+			validate:   validateTag(field),
 		})
 	}
 
@@ -322,6 +348,11 @@ func getFormFields(t reflect.Type) []formFieldInfo {
 
 // decodeMultipartForm 从请求中解析multipart表单数据
 func decodeMultipartForm(form *multipart.Form, v any) error {
+	return decodeForm(form.Value, form.File, v)
+}
+
+// decodeForm 将表单字段和文件绑定到结构体，urlencoded表单没有文件时files为nil
+func decodeForm(values url.Values, files map[string][]*multipart.FileHeader, v any) error {
 	// 获取结构体类型
 	structValue := reflect.ValueOf(v)
 	if structValue.Kind() == reflect.Ptr {
@@ -332,46 +363,54 @@ func decodeMultipartForm(form *multipart.Form, v any) error {
 	// 获取缓存的字段信息
 	fields := getFormFields(structType)
 
+	// 收集所有字段的校验错误
+	verrs := &ValidationError{}
+
 	// 遍历字段信息
 	for _, info := range fields {
 		fieldVal := structValue.Field(info.fieldIndex)
 
 		// 处理文件字段
 		if info.isFile {
-			files := form.File[info.name]
-			if len(files) > 0 {
+			fileHeaders := files[info.name]
+			if len(fileHeaders) > 0 {
 				if info.isSlice {
-					fieldVal.Set(reflect.ValueOf(files))
+					fieldVal.Set(reflect.ValueOf(fileHeaders))
 				} else {
-					fieldVal.Set(reflect.ValueOf(files[0]))
+					fieldVal.Set(reflect.ValueOf(fileHeaders[0]))
 				}
 			} else {
 				// 检查文件字段是否必填
 				canBeEmpty, _ := handleEmptyValue(info.structPath, structType.Field(info.fieldIndex), "name")
 				if !canBeEmpty {
-					return fmt.Errorf("missing required file '%s' in form", info.name)
+					verrs.Add("form", info.name, reasonMissingFile, "")
 				}
 			}
 			continue
 		}
 
 		// 处理普通字段
-		vals := form.Value[info.name]
+		vals := values[info.name]
 		if info.isSlice {
-			if err := handleSliceValue(fieldVal, vals, info.name); err != nil {
-				return err
-			}
+			handleSliceValue(fieldVal, vals, info.name, verrs)
 		} else {
 			var val string
 			if len(vals) > 0 {
 				val = vals[0]
 			}
-			if err := handleFormFieldValue(structType.Field(info.fieldIndex), fieldVal, val, info.name, info.structPath); err != nil {
+			if err := handleFormFieldValue(structType.Field(info.fieldIndex), fieldVal, val, info.name, info.structPath, verrs); err != nil {
 				return err
 			}
 		}
+
+		// 按照validate标签校验非空字段
+		if info.validate != "" && !isEmptyValue(fieldVal) {
+			if err := ValidateValue(fieldVal.Interface(), info.validate); err != nil {
+				verrs.Add("form", info.name, err.Error(), strings.Join(vals, ","))
+			}
+		}
 	}
-	return nil
+	return verrs.Err()
 }
 
 // IsZeroValue 检查值是否为零值（改进版本，更安全）
@@ -0,0 +1,134 @@
+package easygintest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/zboyco/easygin"
+)
+
+// ContractCase 描述一次契约测试
+type ContractCase struct {
+	Name   string            // 子测试名称，为空时使用API的类型名
+	API    easygin.RouterAPI // 填充了请求参数的API
+	Header http.Header       // 额外的请求头，如中间件需要的Authorization
+	Status int               // 期望的状态码，为0时只要求状态码在Responses()中声明
+}
+
+// RunContract 以子测试逐个运行契约测试用例，适用于表驱动测试
+//
+//	srv.RunContract(t,
+//		easygintest.ContractCase{API: &user.GetUser{ID: 1}, Status: http.StatusOK},
+//		easygintest.ContractCase{API: &user.GetUser{ID: 0}, Status: http.StatusNotFound},
+//	)
+func (s *Server) RunContract(t *testing.T, cases ...ContractCase) {
+	t.Helper()
+	for _, c := range cases {
+		name := c.Name
+		if name == "" {
+			name = reflect.Indirect(reflect.ValueOf(c.API)).Type().Name()
+		}
+		t.Run(name, func(t *testing.T) {
+			s.CheckContract(t, c)
+		})
+	}
+}
+
+// CheckContract 通过完整的中间件链调用API，检查响应的状态码和响应体与Responses()的声明一致
+// 返回响应记录，便于继续断言响应内容
+func (s *Server) CheckContract(t testing.TB, c ContractCase) *httptest.ResponseRecorder {
+	t.Helper()
+	w, err := s.checkContract(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w
+}
+
+// checkContract 发送请求并检查响应
+//
+//   - 状态码必须在Responses()中声明，Status明确期望的错误状态码除外，响应体按照错误渲染器的模型检查
+//   - 声明为nil的响应不能有响应体，声明为错误的响应按照错误渲染器的模型检查
+//   - JSON响应体必须能解码为声明的类型，且不能包含声明以外的字段，其他格式的响应体不检查
+func (s *Server) checkContract(c ContractCase) (*httptest.ResponseRecorder, error) {
+	resp := s.Request(c.API).withHeaders(c.Header).Do()
+	if resp.err != nil {
+		return nil, resp.err
+	}
+	w := resp.ResponseRecorder
+
+	if c.Status != 0 && w.Code != c.Status {
+		return w, fmt.Errorf("%T: expected status %d, got %d: %s", c.API, c.Status, w.Code, w.Body.String())
+	}
+
+	model, declared := declaredResponses(c.API)[w.Code]
+	if !declared {
+		if c.Status == 0 || w.Code < http.StatusBadRequest {
+			return w, fmt.Errorf("%T: status %d is not declared in Responses(): %s", c.API, w.Code, w.Body.String())
+		}
+		// 未声明但明确期望的错误响应，如中间件返回的401
+		var e error = easygin.NewError(w.Code, "", "")
+		if w.Code == http.StatusBadRequest {
+			e = &easygin.ValidationError{}
+		}
+		model = e
+	}
+
+	switch v := model.(type) {
+	case nil:
+		if w.Body.Len() > 0 {
+			return w, fmt.Errorf("%T: status %d declares no content, got %s", c.API, w.Code, w.Body.String())
+		}
+		return w, nil
+	case easygin.EventStream, *easygin.EventStream:
+		if mediaType := responseMediaType(w); mediaType != easygin.ContentTypeEventStream {
+			return w, fmt.Errorf("%T: status %d declares event stream, got content type %q", c.API, w.Code, mediaType)
+		}
+		return w, nil
+	case error:
+		model = s.srv.ErrorRenderer().Model(v)
+	}
+
+	mediaType := responseMediaType(w)
+	if mediaType != easygin.MIMEJSON && !strings.HasSuffix(mediaType, "+json") {
+		return w, nil
+	}
+	if err := decodeStrict(w.Body.Bytes(), reflect.TypeOf(model)); err != nil {
+		return w, fmt.Errorf("%T: status %d body does not match %T: %w: %s", c.API, w.Code, model, err, w.Body.String())
+	}
+	return w, nil
+}
+
+// declaredResponses 获取API声明的响应，未实现RouterResponse的API只声明无响应体的200
+func declaredResponses(api easygin.RouterAPI) easygin.R {
+	if responder, ok := api.(easygin.RouterResponse); ok {
+		return responder.Responses()
+	}
+	return easygin.R{http.StatusOK: nil}
+}
+
+// responseMediaType 获取响应的媒体类型，不包含参数
+func responseMediaType(w *httptest.ResponseRecorder) string {
+	mediaType, _, _ := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	return mediaType
+}
+
+// decodeStrict 将JSON解码为类型t，拒绝未知字段和多余的数据
+func decodeStrict(data []byte, t reflect.Type) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(reflect.New(t).Interface()); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return fmt.Errorf("unexpected data after json value")
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package easygintest
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/zboyco/easygin"
+)
+
+func newTestServer() *Server {
+	root := easygin.NewRouterGroup("/api")
+	items := easygin.NewRouterGroup("/items", &testAuth{})
+	items.RegisterAPI(&testGetItem{})
+	items.RegisterAPI(&testDriftItem{})
+	items.RegisterAPI(&testDeleteItem{})
+	items.RegisterAPI(&testWhoAmI{})
+	root.RegisterGroup(items)
+	return NewServer(nil, root)
+}
+
+func TestRunContract(t *testing.T) {
+	srv := newTestServer()
+	auth := http.Header{"Token": {"secret"}}
+
+	srv.RunContract(t,
+		ContractCase{Name: "Found", API: &testGetItem{ID: 1, Fields: "name"}, Header: auth, Status: http.StatusOK},
+		ContractCase{Name: "NotFound", API: &testGetItem{ID: 0}, Header: auth, Status: http.StatusNotFound},
+		ContractCase{Name: "Unauthorized", API: &testGetItem{ID: 1}, Status: http.StatusUnauthorized},
+		ContractCase{Name: "NoContent", API: &testDeleteItem{ID: 1}, Header: auth},
+	)
+
+	w := srv.CheckContract(t, ContractCase{API: &testGetItem{ID: 2, Fields: "id,name"}, Header: auth})
+	if !strings.Contains(w.Body.String(), `"fields":"id,name"`) {
+		t.Fatalf("expected query parameters from api fields, got %s", w.Body.String())
+	}
+}
+
+func TestCheckContractMismatch(t *testing.T) {
+	srv := newTestServer()
+	auth := http.Header{"Token": {"secret"}}
+
+	cases := []struct {
+		name     string
+		c        ContractCase
+		expected string
+	}{
+		{"UnexpectedStatus", ContractCase{API: &testGetItem{ID: 0}, Header: auth, Status: http.StatusOK}, "expected status 200, got 404"},
+		{"UndeclaredStatus", ContractCase{API: &testGetItem{ID: 1}}, "status 401 is not declared in Responses()"},
+		{"BodyDrift", ContractCase{API: &testDriftItem{}, Header: auth}, `unknown field "price"`},
+		{"Unregistered", ContractCase{API: &testUnregistered{}}, "is not registered"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := srv.checkContract(c.c)
+			if err == nil || !strings.Contains(err.Error(), c.expected) {
+				t.Fatalf("expected error containing %q, got %v", c.expected, err)
+			}
+		})
+	}
+}
+
+type testAuth struct {
+	Token string `in:"header" name:"Token,omitempty"`
+}
+
+func (m *testAuth) Output(ctx context.Context) (any, error) {
+	if m.Token == "" {
+		return nil, easygin.NewError(http.StatusUnauthorized, "unauthorized", "")
+	}
+	return &testUser{Name: m.Token}, nil
+}
+
+type testAuthContextKey int
+
+func (testAuth) ContextKey() any {
+	return testAuthContextKey(0)
+}
+
+type testUser struct {
+	Name string `json:"name"`
+}
+
+type testItem struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Fields string `json:"fields,omitempty"`
+}
+
+type testGetItem struct {
+	easygin.MethodGet
+	ID     int    `in:"path" name:"id"`
+	Fields string `in:"query" name:"fields,omitempty"`
+}
+
+func (testGetItem) Path() string {
+	return "/:id"
+}
+
+func (testGetItem) Responses() easygin.R {
+	return easygin.R{
+		http.StatusOK:       &testItem{},
+		http.StatusNotFound: &easygin.Error{},
+	}
+}
+
+func (api *testGetItem) Output(ctx context.Context) (any, error) {
+	if api.ID == 0 {
+		return nil, easygin.NewError(http.StatusNotFound, "item not found", "")
+	}
+	return &testItem{ID: api.ID, Name: "easygin", Fields: api.Fields}, nil
+}
+
+type testDriftItem struct {
+	easygin.MethodGet
+}
+
+func (testDriftItem) Path() string {
+	return "/drift"
+}
+
+func (testDriftItem) Responses() easygin.R {
+	return easygin.R{http.StatusOK: &testItem{}}
+}
+
+func (testDriftItem) Output(ctx context.Context) (any, error) {
+	return map[string]any{"id": 1, "price": 10}, nil
+}
+
+type testDeleteItem struct {
+	easygin.MethodDelete
+	ID int `in:"path" name:"id"`
+}
+
+func (testDeleteItem) Path() string {
+	return "/:id"
+}
+
+func (testDeleteItem) Responses() easygin.R {
+	return easygin.R{http.StatusNoContent: nil}
+}
+
+func (testDeleteItem) Output(ctx context.Context) (any, error) {
+	return nil, nil
+}
+
+type testUnregistered struct {
+	easygin.MethodGet
+}
+
+func (testUnregistered) Path() string {
+	return "/unregistered"
+}
+
+func (testUnregistered) Output(ctx context.Context) (any, error) {
+	return nil, nil
+}
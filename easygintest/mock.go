@@ -0,0 +1,32 @@
+package easygintest
+
+import (
+	"context"
+
+	"github.com/zboyco/easygin"
+)
+
+// Mock 将伪造的中间件输出注入上下文，直接调用API的Output进行单元测试时不需要执行中间件
+// 注入的输出可以通过easygin.FromContext或者中间件提供的获取函数读取
+//
+//	ctx := easygintest.Mock(context.Background(), &middleware.MustAuth{}, &middleware.UserInfo{ID: 1})
+//	resp, err := (&user.GetUser{ID: 1}).Output(ctx)
+func Mock(ctx context.Context, middleware easygin.ContextKey, output any) context.Context {
+	return easygin.ContextWithMiddlewareOutput(ctx, middleware, output)
+}
+
+// MockDep 将伪造的NewMiddleware中间件输出按照类型T注入上下文，配合Call填充API的Dep[T]字段
+//
+//	ctx := easygintest.MockDep(context.Background(), &middleware.UserInfo{ID: 1})
+//	resp, err := easygintest.Call(ctx, &user.GetUser{ID: 1})
+func MockDep[T any](ctx context.Context, value T) context.Context {
+	return easygin.ContextWithDependency(ctx, value)
+}
+
+// Call 从上下文中填充API的Dep字段后调用Output，上下文中缺少依赖时返回错误
+func Call(ctx context.Context, api easygin.RouterAPI) (any, error) {
+	if err := easygin.InjectDependencies(ctx, api); err != nil {
+		return nil, err
+	}
+	return api.Output(ctx)
+}
@@ -0,0 +1,92 @@
+package easygintest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/zboyco/easygin"
+)
+
+func TestMock(t *testing.T) {
+	ctx := Mock(context.Background(), &testAuth{}, &testUser{Name: "mock"})
+	resp, err := (&testWhoAmI{}).Output(ctx)
+	if err != nil || resp.(*testUser).Name != "mock" {
+		t.Fatalf("expected mocked user, got %+v %v", resp, err)
+	}
+
+	if _, ok := easygin.FromContext[string](ctx, &testAuth{}); ok {
+		t.Fatal("expected FromContext to reject mismatched type")
+	}
+	if _, err := (&testWhoAmI{}).Output(context.Background()); err == nil {
+		t.Fatal("expected error without middleware output")
+	}
+
+	user, err := Decode[*testUser](newTestServer().Request(&testWhoAmI{}).WithHeader("Token", "secret").Do())
+	if err != nil || user.Name != "secret" {
+		t.Fatalf("expected user from middleware, got %+v %v", user, err)
+	}
+}
+
+func TestMockDep(t *testing.T) {
+	ctx := MockDep(context.Background(), &testUser{Name: "mock"})
+	resp, err := Call(ctx, &testDepWhoAmI{})
+	if err != nil || resp.(*testUser).Name != "mock" {
+		t.Fatalf("expected mocked dependency, got %+v %v", resp, err)
+	}
+
+	// Mock以ContextKey为键，不能提供Dep[T]
+	ctx = Mock(context.Background(), &testAuth{}, &testUser{Name: "mock"})
+	if _, err := Call(ctx, &testDepWhoAmI{}); err == nil {
+		t.Fatal("expected error without mocked dependency")
+	}
+	if _, err := Call(context.Background(), testValueAPI{}); err == nil {
+		t.Fatal("expected error for non-pointer api")
+	}
+}
+
+type testWhoAmI struct {
+	easygin.MethodGet
+}
+
+func (testWhoAmI) Path() string {
+	return "/me"
+}
+
+func (testWhoAmI) Responses() easygin.R {
+	return easygin.R{http.StatusOK: &testUser{}}
+}
+
+func (testWhoAmI) Output(ctx context.Context) (any, error) {
+	user, ok := easygin.FromContext[*testUser](ctx, &testAuth{})
+	if !ok {
+		return nil, easygin.NewError(http.StatusUnauthorized, "unauthorized", "")
+	}
+	return user, nil
+}
+
+// testDepWhoAmI 通过Dep获取中间件输出
+type testDepWhoAmI struct {
+	easygin.MethodGet
+	User easygin.Dep[*testUser]
+}
+
+func (testDepWhoAmI) Path() string {
+	return "/dep/me"
+}
+
+func (api *testDepWhoAmI) Output(ctx context.Context) (any, error) {
+	return api.User.Get(), nil
+}
+
+type testValueAPI struct {
+	easygin.MethodGet
+}
+
+func (testValueAPI) Path() string {
+	return "/value"
+}
+
+func (testValueAPI) Output(ctx context.Context) (any, error) {
+	return nil, nil
+}
@@ -0,0 +1,182 @@
+package easygintest
+
+import (
+	"fmt"
+	"mime/multipart"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/zboyco/easygin"
+)
+
+var fileHeaderType = reflect.TypeOf(multipart.FileHeader{})
+
+// newClientRequest 根据API结构体的in和name标签创建请求，参数的序列化方式与client命令生成的客户端一致
+// 参数path为API注册的完整路由路径，如/user/:id，MethodAny的API无法确定请求方法，返回错误
+func newClientRequest(api easygin.RouterAPI, path string) (*easygin.ClientRequest, error) {
+	method := strings.ToUpper(api.Method())
+	if method == "ANY" {
+		return nil, fmt.Errorf("%T: MethodAny api is not supported", api)
+	}
+
+	rv := reflect.ValueOf(api)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("%T: api is nil", api)
+		}
+		rv = rv.Elem()
+	}
+
+	r := easygin.NewClientRequest(method, path)
+	if err := setParams(r, rv); err != nil {
+		return nil, fmt.Errorf("%s: %w", rv.Type().String(), err)
+	}
+	return r, nil
+}
+
+// setParams 将结构体字段按照in标签写入请求，嵌入字段的参数与普通字段一样处理
+// 与参数绑定一致，除body外没有name标签的字段不会被绑定，也不写入请求
+func setParams(r *easygin.ClientRequest, rv reflect.Value) error {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		inTag := field.Tag.Get("in")
+
+		if field.Anonymous && inTag == "" {
+			embedValue := rv.Field(i)
+			if embedValue.Kind() == reflect.Ptr {
+				if embedValue.IsNil() {
+					continue
+				}
+				embedValue = embedValue.Elem()
+			}
+			if embedValue.Kind() == reflect.Struct {
+				if err := setParams(r, embedValue); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if inTag == "" {
+			continue
+		}
+
+		value := rv.Field(i)
+		if inTag == "body" {
+			if err := setBody(r, value, field); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name, omitempty, ok := parseNameTag(field)
+		if !ok {
+			continue
+		}
+		switch inTag {
+		case "path":
+			r.SetPathParam(name, value.Interface())
+		case "query":
+			addValue(value, omitempty, func(v any) {
+				r.Query.Add(name, easygin.FormatParameter(v))
+			})
+		case "header":
+			addValue(value, omitempty, func(v any) {
+				r.Header.Add(name, easygin.FormatParameter(v))
+			})
+		case "cookie":
+			addValue(value, omitempty, func(v any) {
+				r.AddCookie(name, v)
+			})
+		}
+	}
+	return nil
+}
+
+// parseNameTag 解析字段的name标签，没有name标签时返回false
+func parseNameTag(field reflect.StructField) (name string, omitempty bool, ok bool) {
+	nameParts := strings.Split(field.Tag.Get("name"), ",")
+	if nameParts[0] == "" {
+		return "", false, false
+	}
+	return nameParts[0], len(nameParts) > 1 && nameParts[1] == "omitempty", true
+}
+
+// addValue 添加单个参数，切片逐个添加，指针为nil时跳过，带omitempty的参数为零值时跳过
+func addValue(value reflect.Value, omitempty bool, add func(v any)) {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return
+		}
+		if value.Elem().Kind() != reflect.Slice {
+			add(value.Interface())
+			return
+		}
+		value = value.Elem()
+	}
+	if value.Kind() == reflect.Slice && value.Type().Elem().Kind() != reflect.Uint8 {
+		for i := 0; i < value.Len(); i++ {
+			add(value.Index(i).Interface())
+		}
+		return
+	}
+	if omitempty && value.IsZero() {
+		return
+	}
+	add(value.Interface())
+}
+
+// setBody 设置请求体
+// multipart和urlencoded表单按照name标签逐个字段序列化，其他请求体编码为JSON
+func setBody(r *easygin.ClientRequest, value reflect.Value, field reflect.StructField) error {
+	mime := field.Tag.Get("mime")
+	if mime != "multipart" && mime != "urlencoded" {
+		return r.SetJSONBody(value.Interface())
+	}
+
+	form := url.Values{}
+	files := map[string][]*multipart.FileHeader{}
+	if value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if value.IsValid() {
+		bodyType := value.Type()
+		for i := 0; i < bodyType.NumField(); i++ {
+			subField := bodyType.Field(i)
+			name, omitempty, ok := parseNameTag(subField)
+			if !ok {
+				continue
+			}
+
+			if isFileField(subField.Type) {
+				if mime == "urlencoded" {
+					return fmt.Errorf("file field '%s' is not supported in `mime:\"urlencoded\"` body", name)
+				}
+				addValue(value.Field(i), false, func(v any) {
+					if header, ok := v.(*multipart.FileHeader); ok && header != nil {
+						files[name] = append(files[name], header)
+					}
+				})
+				continue
+			}
+			addValue(value.Field(i), omitempty, func(v any) {
+				form.Add(name, easygin.FormatParameter(v))
+			})
+		}
+	}
+
+	if mime == "multipart" {
+		return r.SetMultipartBody(form, files)
+	}
+	r.SetURLEncodedBody(form)
+	return nil
+}
+
+// isFileField 判断字段是否为*multipart.FileHeader或[]*multipart.FileHeader
+func isFileField(t reflect.Type) bool {
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Ptr && t.Elem() == fileHeaderType
+}
@@ -0,0 +1,75 @@
+package easygintest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/zboyco/easygin"
+)
+
+func TestNewClientRequest(t *testing.T) {
+	r, err := newClientRequest(&testParamsAPI{ID: 7, Token: "secret", Untagged: "skip", Body: &testParamsBody{Name: "easygin"}}, "/items/:id")
+	if err != nil {
+		t.Fatalf("newClientRequest returned error: %v", err)
+	}
+	if r.Method != http.MethodPost || r.Path != "/items/7" || r.Query.Has("tag") || r.Header.Get("Token") != "secret" {
+		t.Fatalf("unexpected request: %+v", r)
+	}
+	// 没有name标签的字段不会被绑定，也不写入请求
+	if r.Query.Has("untagged") || len(r.Query) != 0 {
+		t.Fatalf("expected untagged field to be skipped, got %v", r.Query)
+	}
+	body, _ := io.ReadAll(r.Body)
+	if r.ContentType != easygin.MIMEJSON || string(body) != `{"name":"easygin"}` {
+		t.Fatalf("unexpected body %s %s", r.ContentType, body)
+	}
+
+	r, err = newClientRequest(&testParamsFormAPI{Body: testParamsForm{GrantType: "password", Untagged: "skip"}}, "/token")
+	if err != nil || r.ContentType != "application/x-www-form-urlencoded" {
+		t.Fatalf("expected urlencoded body, got %+v %v", r, err)
+	}
+	if body, _ := io.ReadAll(r.Body); string(body) != "grant_type=password" {
+		t.Fatalf("expected untagged form field to be skipped, got %s", body)
+	}
+}
+
+type testParamsAPI struct {
+	easygin.MethodPost
+	ID       int             `in:"path" name:"id"`
+	Tag      string          `in:"query" name:"tag,omitempty"`
+	Untagged string          `in:"query"`
+	Token    string          `in:"header" name:"Token,omitempty"`
+	Body     *testParamsBody `in:"body"`
+}
+
+type testParamsBody struct {
+	Name string `json:"name"`
+}
+
+func (testParamsAPI) Path() string {
+	return "/items/:id"
+}
+
+func (testParamsAPI) Output(ctx context.Context) (any, error) {
+	return nil, nil
+}
+
+type testParamsFormAPI struct {
+	easygin.MethodPost
+	Body testParamsForm `in:"body" mime:"urlencoded"`
+}
+
+type testParamsForm struct {
+	GrantType string `name:"grant_type"`
+	Untagged  string
+}
+
+func (testParamsFormAPI) Path() string {
+	return "/token"
+}
+
+func (testParamsFormAPI) Output(ctx context.Context) (any, error) {
+	return nil, nil
+}
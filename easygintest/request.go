@@ -0,0 +1,148 @@
+package easygintest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/zboyco/easygin"
+)
+
+// Request 测试请求构造器，由Server.Request创建
+// 请求参数从API结构体的标签中获取，可以额外添加请求头、Cookie和上下文
+type Request struct {
+	srv     *Server
+	api     easygin.RouterAPI
+	header  http.Header
+	cookies []*http.Cookie
+	ctx     context.Context
+}
+
+// Request 创建API的测试请求构造器
+//
+//	resp := srv.Request(&user.GetUser{ID: 1}).
+//		WithHeader("Authorization", "Bearer token").
+//		Do()
+func (s *Server) Request(api easygin.RouterAPI) *Request {
+	return &Request{
+		srv:    s,
+		api:    api,
+		header: http.Header{},
+	}
+}
+
+// Do 使用默认配置发送API请求，等同于srv.Request(api).Do()
+func (s *Server) Do(api easygin.RouterAPI) *Response {
+	return s.Request(api).Do()
+}
+
+// WithHeader 添加请求头，覆盖API结构体和Server.Header中的同名请求头
+// 返回修改后的Request实例，支持链式调用
+func (r *Request) WithHeader(key, value string) *Request {
+	r.header.Add(key, value)
+	return r
+}
+
+// WithCookie 添加Cookie
+// 返回修改后的Request实例，支持链式调用
+func (r *Request) WithCookie(name, value string) *Request {
+	r.cookies = append(r.cookies, &http.Cookie{Name: name, Value: value})
+	return r
+}
+
+// WithContext 设置请求的上下文，如设置超时或者注入链路追踪信息
+// 返回修改后的Request实例，支持链式调用
+func (r *Request) WithContext(ctx context.Context) *Request {
+	r.ctx = ctx
+	return r
+}
+
+// withHeaders 添加多个请求头
+func (r *Request) withHeaders(header http.Header) *Request {
+	for key, values := range header {
+		for _, value := range values {
+			r.header.Add(key, value)
+		}
+	}
+	return r
+}
+
+// Build 创建http.Request
+func (r *Request) Build() (*http.Request, error) {
+	req, err := r.srv.NewRequest(r.api)
+	if err != nil {
+		return nil, err
+	}
+	if r.ctx != nil {
+		req = req.WithContext(r.ctx)
+	}
+	for _, header := range []http.Header{r.srv.Header, r.header} {
+		for key, values := range header {
+			req.Header[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+		}
+	}
+	for _, cookie := range r.cookies {
+		req.AddCookie(cookie)
+	}
+	return req, nil
+}
+
+// Do 在进程内发送请求，创建请求失败时通过Response.Err返回错误
+func (r *Request) Do() *Response {
+	resp := &Response{ResponseRecorder: httptest.NewRecorder()}
+	req, err := r.Build()
+	if err != nil {
+		resp.err = err
+		return resp
+	}
+	r.srv.ServeHTTP(resp.ResponseRecorder, req)
+	return resp
+}
+
+// Response 测试响应，嵌入httptest.ResponseRecorder便于断言状态码、响应头和响应体
+type Response struct {
+	*httptest.ResponseRecorder
+	err error // 创建请求失败时的错误
+}
+
+// Err 获取请求的错误，创建请求失败时返回对应的错误，非2xx响应返回解码后的*easygin.Error
+func (r *Response) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+	if r.Code < http.StatusOK || r.Code >= http.StatusMultipleChoices {
+		return easygin.DecodeErrorResponse(&http.Response{
+			StatusCode: r.Code,
+			Header:     r.Header(),
+			Body:       io.NopCloser(bytes.NewReader(r.Body.Bytes())),
+		})
+	}
+	return nil
+}
+
+// Decode 按照Content-Type对应的Codec将2xx响应体解码到v，v必须是指针
+// 未携带Content-Type时按照JSON解码，请求失败时返回Err的结果
+func (r *Response) Decode(v any) error {
+	if err := r.Err(); err != nil {
+		return err
+	}
+	codec := easygin.Codec(easygin.JSONCodec{})
+	if mediaType, _, err := mime.ParseMediaType(r.Header().Get("Content-Type")); err == nil {
+		if c, ok := easygin.LookupCodec(mediaType); ok {
+			codec = c
+		}
+	}
+	return codec.Decode(bytes.NewReader(r.Body.Bytes()), v)
+}
+
+// Decode 将响应体解码为T类型，适用于直接获取API的返回值
+//
+//	u, err := easygintest.Decode[user.RespGetUser](srv.Do(&user.GetUser{ID: 1}))
+func Decode[T any](resp *Response) (T, error) {
+	var v T
+	err := resp.Decode(&v)
+	return v, err
+}
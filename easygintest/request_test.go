@@ -0,0 +1,66 @@
+package easygintest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/zboyco/easygin"
+)
+
+func TestRequestDo(t *testing.T) {
+	srv := newTestServer()
+
+	t.Run("Decode", func(t *testing.T) {
+		item, err := Decode[*testItem](srv.Request(&testGetItem{ID: 3, Fields: "name"}).WithHeader("Token", "secret").Do())
+		if err != nil {
+			t.Fatalf("Decode returned error: %v", err)
+		}
+		if *item != (testItem{ID: 3, Name: "easygin", Fields: "name"}) {
+			t.Fatalf("unexpected item: %+v", item)
+		}
+	})
+
+	t.Run("DefaultHeader", func(t *testing.T) {
+		srv := newTestServer()
+		srv.Header.Set("Token", "secret")
+		if resp := srv.Do(&testDeleteItem{ID: 1}); resp.Code != http.StatusNoContent || resp.Err() != nil {
+			t.Fatalf("expected 204, got %d %v", resp.Code, resp.Err())
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		var item testItem
+		err := srv.Request(&testGetItem{ID: 0}).WithHeader("Token", "secret").Do().Decode(&item)
+		var e *easygin.Error
+		if !errors.As(err, &e) || e.C != http.StatusNotFound || e.M != "item not found" {
+			t.Fatalf("expected decoded *easygin.Error, got %#v", err)
+		}
+		if err := srv.Do(&testGetItem{ID: 1}).Err(); !errors.As(err, &e) || e.C != http.StatusUnauthorized {
+			t.Fatalf("expected 401 from middleware, got %#v", err)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		req, err := srv.Request(&testGetItem{ID: 1}).WithContext(ctx).WithCookie("session", "abc").Build()
+		if err != nil {
+			t.Fatalf("Build returned error: %v", err)
+		}
+		if req.Context() != ctx || req.URL.Path != "/api/items/1" {
+			t.Fatalf("unexpected request: %s %v", req.URL, req.Context())
+		}
+		if cookie, err := req.Cookie("session"); err != nil || cookie.Value != "abc" {
+			t.Fatalf("expected session cookie, got %v %v", cookie, err)
+		}
+	})
+
+	t.Run("Unregistered", func(t *testing.T) {
+		if err := srv.Do(&testUnregistered{}).Err(); err == nil {
+			t.Fatal("expected error for unregistered api")
+		}
+	})
+}
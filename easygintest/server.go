@@ -0,0 +1,101 @@
+// Package easygintest 提供测试easygin API的辅助工具
+package easygintest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"reflect"
+	"strings"
+
+	"github.com/zboyco/easygin"
+)
+
+// Server 在进程内处理请求的测试服务器，不监听端口
+// 请求经过与Run启动的服务器相同的中间件链
+type Server struct {
+	Header http.Header // 每个请求都会携带的请求头，如中间件需要的Authorization
+
+	srv     *easygin.Server
+	handler http.Handler
+	routes  map[reflect.Type]string // API类型到完整路由路径的映射
+}
+
+// NewServer 根据路由组创建测试服务器
+// 参数srv用于设置错误渲染器、上下文注入等配置，为nil时使用easygin.NewServer的默认配置
+func NewServer(srv *easygin.Server, groups ...*easygin.RouterGroup) *Server {
+	if srv == nil {
+		srv = easygin.NewServer("easygintest", "", false)
+	}
+	s := &Server{
+		Header:  http.Header{},
+		srv:     srv,
+		handler: srv.Handler(groups...),
+		routes:  make(map[reflect.Type]string),
+	}
+	for _, group := range groups {
+		s.collectRoutes("/", group)
+	}
+	return s
+}
+
+// collectRoutes 递归记录路由组中每个API的完整路由路径，路径拼接方式与gin一致
+func (s *Server) collectRoutes(basePath string, group *easygin.RouterGroup) {
+	groupPath := joinPaths(basePath, group.Path())
+	for _, api := range group.APIs() {
+		apiType := reflect.TypeOf(api)
+		if _, exists := s.routes[apiType]; !exists {
+			s.routes[apiType] = joinPaths(groupPath, api.Path())
+		}
+	}
+	for _, child := range group.Children() {
+		s.collectRoutes(groupPath, child)
+	}
+}
+
+// joinPaths 拼接路由路径，相对路径以斜杠结尾时保留结尾的斜杠
+func joinPaths(absolutePath, relativePath string) string {
+	if relativePath == "" {
+		return absolutePath
+	}
+	finalPath := path.Join(absolutePath, relativePath)
+	if strings.HasSuffix(relativePath, "/") && !strings.HasSuffix(finalPath, "/") {
+		return finalPath + "/"
+	}
+	return finalPath
+}
+
+// ServeHTTP 在进程内处理请求
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.handler.ServeHTTP(w, req)
+}
+
+// NewRequest 根据API结构体的标签创建请求，API必须已注册到创建服务器的路由组中
+func (s *Server) NewRequest(api easygin.RouterAPI) (*http.Request, error) {
+	routePath, ok := s.routes[reflect.TypeOf(api)]
+	if !ok {
+		return nil, fmt.Errorf("%T is not registered", api)
+	}
+	r, err := newClientRequest(api, routePath)
+	if err != nil {
+		return nil, err
+	}
+
+	target := r.Path
+	if len(r.Query) > 0 {
+		target += "?" + r.Query.Encode()
+	}
+	req := httptest.NewRequest(r.Method, target, r.Body)
+	for key, values := range r.Header {
+		req.Header[key] = append([]string(nil), values...)
+	}
+	for _, cookie := range r.Cookies {
+		req.AddCookie(cookie)
+	}
+	if r.ContentType != "" {
+		req.Header.Set("Content-Type", r.ContentType)
+	}
+	req.Header.Set("Accept", easygin.MIMEJSON)
+	return req, nil
+}
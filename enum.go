@@ -0,0 +1,229 @@
+package easygin
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// EnumValues 定义了枚举类型的接口
+// 字符串或整数的具名类型实现此接口后，OpenAPI文档中生成enum和x-enum-varnames，
+// 参数绑定和JSON请求体校验时不在枚举值中的取值返回400
+//
+//	type Status string
+//
+//	func (Status) EnumValues() []any {
+//		return []any{StatusActive, StatusDisabled}
+//	}
+type EnumValues interface {
+	EnumValues() []any
+}
+
+// EnumVarNames 定义了枚举常量名的接口，返回值与EnumValues一一对应，用于生成x-enum-varnames
+// 未实现时使用类型名加枚举值生成，如StatusActive
+type EnumVarNames interface {
+	EnumVarNames() []string
+}
+
+var enumValuesType = reflect.TypeOf((*EnumValues)(nil)).Elem()
+
+// enumValuesOf 获取实现了EnumValues接口的类型的枚举值，其他类型返回nil
+func enumValuesOf(t reflect.Type) []any {
+	if t.Kind() == reflect.Ptr || !implements(t, enumValuesType) {
+		return nil
+	}
+	return reflect.New(t).Interface().(EnumValues).EnumValues()
+}
+
+// enumSchema 为实现了EnumValues接口的类型生成schema，其他类型返回nil
+func enumSchema(t reflect.Type) *openapi3.Schema {
+	values := enumValuesOf(t)
+	if len(values) == 0 {
+		return nil
+	}
+
+	var schema *openapi3.Schema
+	switch t.Kind() {
+	case reflect.String:
+		schema = openapi3.NewStringSchema()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		schema = openapi3.NewIntegerSchema()
+	case reflect.Float32, reflect.Float64:
+		schema = openapi3.NewFloat64Schema()
+	default:
+		panic("EnumValues is only supported on string and number types, got " + t.String())
+	}
+
+	varNames := make([]string, 0, len(values))
+	for _, value := range values {
+		schema.Enum = append(schema.Enum, enumBaseValue(value))
+		varNames = append(varNames, enumVarName(t.Name(), value))
+	}
+	if named, ok := reflect.New(t).Interface().(EnumVarNames); ok {
+		if names := named.EnumVarNames(); len(names) == len(values) {
+			varNames = names
+		}
+	}
+	setSchemaExtension(schema, "x-enum-varnames", varNames)
+	return schema
+}
+
+// applyEnumTag 将字段的enum标签添加到schema，数组字段作用于元素
+//
+//	enum:"asc,desc"
+func applyEnumTag(schema *openapi3.Schema, field reflect.StructField) {
+	tag := field.Tag.Get("enum")
+	if schema == nil || tag == "" {
+		return
+	}
+	if _, isRef := schema.Extensions["$ref"]; isRef {
+		return
+	}
+
+	target := schema
+	if schema.Type.Is(openapi3.TypeArray) {
+		if schema.Items == nil || schema.Items.Value == nil {
+			return
+		}
+		target = schema.Items.Value
+	}
+
+	values := strings.Split(tag, ",")
+	target.Enum = make([]any, 0, len(values))
+	varNames := make([]string, 0, len(values))
+	for _, value := range values {
+		target.Enum = append(target.Enum, enumSchemaValue(target, value))
+		varNames = append(varNames, enumVarName(field.Name, value))
+	}
+	setSchemaExtension(target, "x-enum-varnames", varNames)
+}
+
+// validateTag 获取字段的校验规则
+// enum标签以及EnumValues接口声明的枚举值合并为enum规则，与validate标签中的规则一起校验
+// 启动时由validateEnumTags检查枚举声明，不能同时在validate标签中声明enum规则，枚举值不能包含逗号和竖线
+func validateTag(field reflect.StructField) string {
+	tag := field.Tag.Get("validate")
+
+	values, _ := fieldEnumValues(field)
+	if len(values) == 0 {
+		return tag
+	}
+
+	// pattern规则必须是最后一条，enum规则放在最前面
+	rule := "enum=" + strings.Join(values, "|")
+	if tag == "" {
+		return rule
+	}
+	return rule + "," + tag
+}
+
+// fieldEnumValues 获取字段通过enum标签或者EnumValues接口声明的枚举值，source为声明方式
+func fieldEnumValues(field reflect.StructField) (values []string, source string) {
+	if enumTag := field.Tag.Get("enum"); enumTag != "" {
+		return strings.Split(enumTag, ","), "enum tag"
+	}
+
+	elemType := field.Type
+	for elemType.Kind() == reflect.Ptr || elemType.Kind() == reflect.Slice || elemType.Kind() == reflect.Array {
+		elemType = elemType.Elem()
+	}
+	for _, value := range enumValuesOf(elemType) {
+		values = append(values, FormatParameter(value))
+	}
+	return values, "EnumValues of " + elemType.String()
+}
+
+// checkEnumRule 检查字段的枚举声明能否合并为enum规则
+func checkEnumRule(field reflect.StructField) error {
+	values, source := fieldEnumValues(field)
+	if len(values) == 0 {
+		return nil
+	}
+	if hasEnumRule(field.Tag.Get("validate")) {
+		return fmt.Errorf("field %s declares enum by both %s and validate tag", field.Name, source)
+	}
+	for _, value := range values {
+		if strings.ContainsAny(value, ",|") {
+			return fmt.Errorf("enum value '%s' of field %s (%s) must not contain ',' or '|'", value, field.Name, source)
+		}
+	}
+	return nil
+}
+
+// hasEnumRule 判断validate标签中是否声明了enum规则，pattern规则之后的内容属于正则表达式
+func hasEnumRule(tag string) bool {
+	rest := tag
+	for rest != "" && !strings.HasPrefix(rest, "pattern=") {
+		rule := rest
+		if i := strings.Index(rest, ","); i >= 0 {
+			rule, rest = rest[:i], rest[i+1:]
+		} else {
+			rest = ""
+		}
+		if key, _, _ := strings.Cut(strings.TrimSpace(rule), "="); key == "enum" {
+			return true
+		}
+	}
+	return false
+}
+
+// validateEnumTags 启动时检查处理器及其嵌套结构体中字段的枚举声明
+func validateEnumTags(t reflect.Type) error {
+	return validateEnumTagsVisited(t, make(map[reflect.Type]bool))
+}
+
+func validateEnumTagsVisited(t reflect.Type, visited map[reflect.Type]bool) error {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	// 已访问过的类型直接跳过，避免循环引用导致无限递归
+	if t.Kind() != reflect.Struct || visited[t] {
+		return nil
+	}
+	visited[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if err := checkEnumRule(field); err != nil {
+			return fmt.Errorf("%s: %w", t.String(), err)
+		}
+		if err := validateEnumTagsVisited(field.Type, visited); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enumBaseValue 将具名类型的枚举值转换为基础类型，保证文档中的取值与JSON序列化一致
+func enumBaseValue(value any) any {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return rv.Uint()
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	}
+	return value
+}
+
+// enumVarName 使用前缀和枚举值生成常量名，如Status和active生成StatusActive
+func enumVarName(prefix string, value any) string {
+	var builder strings.Builder
+	builder.WriteString(prefix)
+	parts := strings.FieldsFunc(FormatParameter(value), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, part := range parts {
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		builder.WriteString(string(runes))
+	}
+	return builder.String()
+}
@@ -0,0 +1,231 @@
+package easygin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+)
+
+func TestEnumSchema(t *testing.T) {
+	doc := &openapi3.T{
+		Paths:      openapi3.NewPaths(),
+		Components: &openapi3.Components{Schemas: openapi3.Schemas{}},
+	}
+
+	schema := generateSchemaValue(doc, reflect.TypeOf(TestEnumPayload{}), false)
+
+	status := schema.Properties["status"].Value
+	if !status.Type.Is(openapi3.TypeString) || !reflect.DeepEqual(status.Enum, []any{"active", "disabled"}) ||
+		!reflect.DeepEqual(status.Extensions["x-enum-varnames"], []string{"TestStatusActive", "TestStatusDisabled"}) {
+		t.Fatalf("expected string enum with derived varnames, got %+v", status)
+	}
+
+	level := schema.Properties["level"].Value
+	if !level.Type.Is(openapi3.TypeInteger) || !reflect.DeepEqual(level.Enum, []any{int64(1), int64(2)}) ||
+		!reflect.DeepEqual(level.Extensions["x-enum-varnames"], []string{"TestLevelLow", "TestLevelHigh"}) {
+		t.Fatalf("expected integer enum with EnumVarNames, got %+v", level)
+	}
+
+	if items := schema.Properties["history"].Value.Items.Value; !reflect.DeepEqual(items.Enum, []any{"active", "disabled"}) {
+		t.Fatalf("expected enum on array items, got %+v", items)
+	}
+
+	sort := schema.Properties["sort"].Value
+	if !reflect.DeepEqual(sort.Enum, []any{"asc", "desc"}) || !reflect.DeepEqual(sort.Extensions["x-enum-varnames"], []string{"SortAsc", "SortDesc"}) {
+		t.Fatalf("expected enum from tag, got %+v", sort)
+	}
+}
+
+func TestValidateTag(t *testing.T) {
+	field, _ := reflect.TypeOf(TestEnumHandler{}).FieldByName("Sort")
+	if tag := validateTag(field); tag != "enum=asc|desc,pattern=^[a-z]+$" {
+		t.Fatalf("expected enum rule before pattern, got %q", tag)
+	}
+	field, _ = reflect.TypeOf(TestEnumPayload{}).FieldByName("History")
+	if tag := validateTag(field); tag != "enum=active|disabled" {
+		t.Fatalf("expected enum rule from element type, got %q", tag)
+	}
+}
+
+func TestValidateEnumTags(t *testing.T) {
+	if err := validateEnumTags(reflect.TypeOf(&TestEnumHandler{})); err != nil {
+		t.Fatalf("expected valid enum declarations, got %v", err)
+	}
+
+	cases := []struct {
+		value    any
+		contains string
+	}{
+		// enum标签与validate标签中的enum规则冲突
+		{struct {
+			Sort string `in:"query" name:"sort" enum:"asc,desc" validate:"enum=up|down"`
+		}{}, "declares enum by both enum tag and validate tag"},
+		// EnumValues与validate标签中的enum规则冲突，pattern中的enum=不算作规则
+		{struct {
+			Payload struct {
+				Status TestStatus `json:"status" validate:"len=6,enum=active"`
+			} `in:"body"`
+		}{}, "declares enum by both EnumValues of easygin.TestStatus and validate tag"},
+		// 枚举值包含规则分隔符
+		{struct {
+			Mode string `in:"query" name:"mode" enum:"a|b,c"`
+		}{}, "enum value 'a|b' of field Mode"},
+		{struct {
+			Kind []TestDelimiterEnum `in:"query" name:"kind"`
+		}{}, "enum value 'x,y' of field Kind"},
+	}
+	for _, c := range cases {
+		err := validateEnumTags(reflect.TypeOf(c.value))
+		if err == nil || !strings.Contains(err.Error(), c.contains) {
+			t.Fatalf("expected error containing %q, got %v", c.contains, err)
+		}
+	}
+
+	if err := validateEnumTags(reflect.TypeOf(struct {
+		Code string `in:"query" name:"code" enum:"a,b" validate:"pattern=^(enum=)?[a-z]$"`
+	}{})); err != nil {
+		t.Fatalf("expected enum= inside pattern to be ignored, got %v", err)
+	}
+
+	// 启动时直接panic
+	root := NewRouterGroup("/")
+	root.RegisterAPI(&TestEnumConflictHandler{})
+	assertSetupPanics(t, root, "declares enum by both enum tag and validate tag")
+}
+
+func TestBindParamsEnum(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Invalid", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/enum?status=deleted&level=3", strings.NewReader(`{"status":"active","level":2,"history":["unknown"],"sort":"up"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Sort", "random")
+
+		recorder := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(recorder)
+		ctx.Request = req
+
+		_, err := bindParams(ctx, &TestEnumHandler{})
+		verrs, ok := err.(*ValidationError)
+		if !ok || verrs.StatusCode() != http.StatusBadRequest {
+			t.Fatalf("expected *ValidationError, got %T: %v", err, err)
+		}
+
+		expected := map[string]string{
+			"query status": "must be one of [active, disabled]",
+			"query level":  "must be one of [1, 2]",
+			"header Sort":  "must be one of [asc, desc]",
+			"body history": "item 0 must be one of [active, disabled]",
+			"body sort":    "must be one of [asc, desc]",
+		}
+		if len(verrs.Errors) != len(expected) {
+			t.Fatalf("expected %d field errors, got %+v", len(expected), verrs.Errors)
+		}
+		for _, fe := range verrs.Errors {
+			if reason := expected[fe.In+" "+fe.Name]; reason != fe.Reason {
+				t.Fatalf("unexpected field error %+v", fe)
+			}
+		}
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/enum?status=disabled&level=1", strings.NewReader(`{"status":"active","level":2,"history":["active"],"sort":"asc"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Sort", "desc")
+
+		bound := bindHandlerForTest(t, &TestEnumHandler{}, req, nil).(*TestEnumHandler)
+		if bound.Status != TestStatusDisabled || bound.Level != TestLevelLow || bound.Sort != "desc" {
+			t.Fatalf("unexpected bound values: %+v", bound)
+		}
+	})
+}
+
+func TestGenerateBindParametersMethodEnum(t *testing.T) {
+	output := generateBindParametersMethod(reflect.TypeOf(TestEnumHandler{}), reflect.TypeOf(TestEnumHandler{}).PkgPath())
+
+	for _, expected := range []string{
+		`easygin.ValidateValue(r.Status, "enum=active|disabled")`,
+		`easygin.ValidateValue(r.Level, "enum=1|2")`,
+		`easygin.ValidateValue(r.Sort, "enum=asc|desc,pattern=^[a-z]+$")`,
+		"easygin.ValidateJsonFields(reflect.ValueOf(&r.Body), &verrs)",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("expected generated code to contain %q, got:\n%s", expected, output)
+		}
+	}
+}
+
+type TestStatus string
+
+const (
+	TestStatusActive   TestStatus = "active"
+	TestStatusDisabled TestStatus = "disabled"
+)
+
+func (TestStatus) EnumValues() []any {
+	return []any{TestStatusActive, TestStatusDisabled}
+}
+
+type TestEnumLevel int
+
+const (
+	TestLevelLow  TestEnumLevel = 1
+	TestLevelHigh TestEnumLevel = 2
+)
+
+func (TestEnumLevel) EnumValues() []any {
+	return []any{TestLevelLow, TestLevelHigh}
+}
+
+func (TestEnumLevel) EnumVarNames() []string {
+	return []string{"TestLevelLow", "TestLevelHigh"}
+}
+
+type TestEnumHandler struct {
+	MethodPost
+	Status TestStatus      `in:"query" name:"status"`
+	Level  TestEnumLevel   `in:"query" name:"level"`
+	Sort   string          `in:"header" name:"Sort" enum:"asc,desc" validate:"pattern=^[a-z]+$"`
+	Body   TestEnumPayload `in:"body"`
+}
+
+type TestEnumPayload struct {
+	Status  TestStatus    `json:"status"`
+	Level   TestEnumLevel `json:"level"`
+	History []TestStatus  `json:"history"`
+	Sort    string        `json:"sort,omitempty" enum:"asc,desc"`
+}
+
+func (TestEnumHandler) Path() string {
+	return "/enum"
+}
+
+func (h *TestEnumHandler) Output(ctx context.Context) (any, error) {
+	return nil, nil
+}
+
+// TestDelimiterEnum 枚举值包含逗号
+type TestDelimiterEnum string
+
+func (TestDelimiterEnum) EnumValues() []any {
+	return []any{TestDelimiterEnum("x,y")}
+}
+
+type TestEnumConflictHandler struct {
+	MethodGet
+	Sort string `in:"query" name:"sort" enum:"asc,desc" validate:"enum=up|down"`
+}
+
+func (TestEnumConflictHandler) Path() string {
+	return "/enum-conflict"
+}
+
+func (h *TestEnumConflictHandler) Output(ctx context.Context) (any, error) {
+	return nil, nil
+}
@@ -0,0 +1,160 @@
+package easygin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ErrorRenderer 定义了错误响应的渲染方式
+// API和中间件返回的错误、404和panic恢复都通过Server上设置的ErrorRenderer渲染
+type ErrorRenderer interface {
+	RenderError(c *gin.Context, err error) // 将错误写入响应并中止后续处理
+	ContentType() string                   // 返回错误响应的Content-Type，用于生成OpenAPI文档
+	Model(err error) any                   // 返回err对应的响应体模型，用于生成OpenAPI文档
+}
+
+// ContentTypeProblemJSON RFC 7807定义的问题详情Content-Type
+const ContentTypeProblemJSON = "application/problem+json"
+
+// errorStatusCode 获取错误对应的HTTP状态码，支持包装的ErrorHttp，未实现ErrorHttp的错误返回500
+func errorStatusCode(err error) int {
+	var errorHttp ErrorHttp
+	if errors.As(err, &errorHttp) {
+		return errorHttp.StatusCode()
+	}
+	return http.StatusInternalServerError
+}
+
+// JSONErrorRenderer 默认的错误渲染器
+// 响应体为{"code","msg","desc"}，参数校验错误额外附带errors字段
+type JSONErrorRenderer struct{}
+
+func (JSONErrorRenderer) RenderError(c *gin.Context, err error) {
+	// 参数校验错误附带所有绑定或校验失败的字段
+	var verrs *ValidationError
+	if errors.As(err, &verrs) {
+		c.AbortWithStatusJSON(verrs.StatusCode(), verrs.Response())
+		return
+	}
+
+	if errorHttp, ok := err.(ErrorHttp); ok {
+		resp := gin.H{
+			"code": errorHttp.StatusCode(),
+			"msg":  errorHttp.Error(),
+			"desc": errorHttp.Desc(),
+		}
+		c.AbortWithStatusJSON(errorHttp.StatusCode(), resp)
+		return
+	}
+
+	resp := gin.H{
+		"code": 500,
+		"msg":  err.Error(),
+		"desc": "Internal Server Error",
+	}
+	c.AbortWithStatusJSON(500, resp)
+}
+
+func (JSONErrorRenderer) ContentType() string {
+	return "application/json"
+}
+
+func (JSONErrorRenderer) Model(err error) any {
+	var verrs *ValidationError
+	if errors.As(err, &verrs) {
+		return &ValidationErrorResponse{}
+	}
+	return &Error{}
+}
+
+// Problem RFC 7807定义的问题详情响应体
+type Problem struct {
+	Type     string       `json:"type" desc:"问题类型URI，默认为about:blank"`
+	Title    string       `json:"title" desc:"问题标题"`
+	Status   int          `json:"status" desc:"HTTP状态码"`
+	Detail   string       `json:"detail,omitempty" desc:"问题详情"`
+	Instance string       `json:"instance,omitempty" desc:"发生问题的请求路径"`
+	TraceID  string       `json:"traceId,omitempty" desc:"链路追踪ID"`
+	Errors   []FieldError `json:"errors,omitempty" desc:"绑定或校验失败的字段列表"`
+}
+
+// ProblemErrorRenderer 以application/problem+json格式渲染错误
+// ErrorHttp的Error()作为title，Desc()作为detail，参数校验错误额外附带errors字段
+// title为同一类问题共用的简短描述，Error()为空时使用状态码对应的文本
+type ProblemErrorRenderer struct {
+	TypeURI string // 问题类型URI，为空时使用about:blank
+}
+
+// NewProblemErrorRenderer 创建一个application/problem+json格式的错误渲染器
+func NewProblemErrorRenderer() *ProblemErrorRenderer {
+	return &ProblemErrorRenderer{}
+}
+
+// WithTypeURI 设置问题类型URI
+// 返回修改后的ProblemErrorRenderer实例，支持链式调用
+func (r *ProblemErrorRenderer) WithTypeURI(uri string) *ProblemErrorRenderer {
+	r.TypeURI = uri
+	return r
+}
+
+func (r *ProblemErrorRenderer) RenderError(c *gin.Context, err error) {
+	problem := r.Problem(c, err)
+	// 预先设置Content-Type，JSON渲染时不会覆盖
+	c.Header("Content-Type", ContentTypeProblemJSON)
+	c.AbortWithStatusJSON(problem.Status, problem)
+}
+
+// Problem 将错误转换为问题详情
+func (r *ProblemErrorRenderer) Problem(c *gin.Context, err error) *Problem {
+	problem := &Problem{
+		Type:   r.TypeURI,
+		Status: errorStatusCode(err),
+	}
+	if problem.Type == "" {
+		problem.Type = "about:blank"
+	}
+
+	problem.Title = http.StatusText(problem.Status)
+	problem.Detail = err.Error()
+	var verrs *ValidationError
+	var errorHttp ErrorHttp
+	switch {
+	case errors.As(err, &verrs):
+		// 参数校验错误的Error()包含具体字段，title使用固定的Desc()，字段错误放在errors中
+		problem.Title = verrs.Desc()
+		problem.Detail = verrs.Error()
+		problem.Errors = verrs.Errors
+	case errors.As(err, &errorHttp):
+		if msg := errorHttp.Error(); msg != "" {
+			problem.Title = msg
+		}
+		problem.Detail = errorHttp.Desc()
+	}
+
+	if c != nil && c.Request != nil {
+		problem.Instance = c.Request.URL.Path
+		problem.TraceID = traceIDFromContext(c.Request.Context())
+	}
+	return problem
+}
+
+func (r *ProblemErrorRenderer) ContentType() string {
+	return ContentTypeProblemJSON
+}
+
+func (r *ProblemErrorRenderer) Model(err error) any {
+	return &Problem{}
+}
+
+// traceIDFromContext 获取上下文中的链路追踪ID，不存在时返回空字符串
+func traceIDFromContext(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
@@ -0,0 +1,129 @@
+package easygin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestProblemErrorRenderer(t *testing.T) {
+	srv := NewServer("test", ":0", false).WithErrorRenderer(NewProblemErrorRenderer())
+
+	root := NewRouterGroup("/")
+	root.RegisterAPI(&testProblemAPI{})
+	root.RegisterAPI(&testPanicAPI{})
+	root.RegisterAPI(&testWrappedProblemAPI{})
+	srv.setup(root)
+
+	cases := []struct {
+		path   string
+		status int
+		title  string
+		detail string
+	}{
+		{"/problem", http.StatusNotFound, "user not found", "user 1 does not exist"},
+		{"/wrapped", http.StatusConflict, "user conflict", "user 1 already exists"},
+		{"/missing", http.StatusNotFound, "404 page not found", "404 page not found"},
+		{"/panic", http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), "boom"},
+	}
+
+	for _, c := range cases {
+		recorder := httptest.NewRecorder()
+		srv.engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, c.path, nil))
+
+		if recorder.Code != c.status {
+			t.Fatalf("%s: expected status %d, got %d", c.path, c.status, recorder.Code)
+		}
+		if ct := recorder.Header().Get("Content-Type"); ct != ContentTypeProblemJSON {
+			t.Fatalf("%s: expected content type %s, got %s", c.path, ContentTypeProblemJSON, ct)
+		}
+
+		var problem Problem
+		if err := json.Unmarshal(recorder.Body.Bytes(), &problem); err != nil {
+			t.Fatalf("%s: decode problem failed: %v", c.path, err)
+		}
+		if problem.Type != "about:blank" || problem.Status != c.status || problem.Title != c.title || problem.Detail != c.detail || problem.Instance != c.path {
+			t.Fatalf("%s: unexpected problem %+v", c.path, problem)
+		}
+	}
+
+	// WrapError的内部错误放在detail中，title保持不变；Error()为空时title使用状态码对应的文本
+	problem := NewProblemErrorRenderer().Problem(nil, WrapError(fmt.Errorf("dial tcp: connection refused"), http.StatusBadGateway, "query user failed"))
+	if problem.Title != "query user failed" || problem.Detail != "dial tcp: connection refused" {
+		t.Fatalf("unexpected wrapped problem %+v", problem)
+	}
+	problem = NewProblemErrorRenderer().Problem(nil, NewError(http.StatusBadGateway, "", "upstream timeout"))
+	if problem.Title != http.StatusText(http.StatusBadGateway) || problem.Detail != "upstream timeout" {
+		t.Fatalf("unexpected problem without message %+v", problem)
+	}
+
+	// 参数校验错误的title固定，具体的失败原因放在detail中
+	verrs := &ValidationError{Errors: []FieldError{{In: "query", Name: "size", Reason: "required"}}}
+	problem = NewProblemErrorRenderer().Problem(nil, verrs)
+	if problem.Title != "invalid parameters" || problem.Detail != verrs.Error() || len(problem.Errors) != 1 {
+		t.Fatalf("unexpected validation problem %+v", problem)
+	}
+}
+
+func TestErrorRendererOpenAPIDefaultResponse(t *testing.T) {
+	doc := &openapi3.T{
+		Paths:      openapi3.NewPaths(),
+		Components: &openapi3.Components{Schemas: openapi3.Schemas{}},
+	}
+
+	root := NewRouterGroup("/")
+	root.RegisterAPI(&testProblemAPI{})
+	if err := generateGroupPaths(doc, NewProblemErrorRenderer(), root, "", nil); err != nil {
+		t.Fatalf("generateGroupPaths returned error: %v", err)
+	}
+
+	op := doc.Paths.Value("/problem").Get
+	media := op.Responses.Default().Value.Content.Get(ContentTypeProblemJSON)
+	if media == nil {
+		t.Fatalf("expected default response with %s, got %+v", ContentTypeProblemJSON, op.Responses.Default().Value.Content)
+	}
+	if ref, _ := media.Schema.Value.Extensions["$ref"].(string); ref != "#/components/schemas/GithubComZboycoEasyginProblem" {
+		t.Fatalf("expected default response to reference Problem schema, got %q", ref)
+	}
+}
+
+type testProblemAPI struct {
+	MethodGet
+}
+
+func (testProblemAPI) Path() string {
+	return "/problem"
+}
+
+func (testProblemAPI) Output(ctx context.Context) (any, error) {
+	return nil, NewError(http.StatusNotFound, "user not found", "user 1 does not exist")
+}
+
+type testWrappedProblemAPI struct {
+	MethodGet
+}
+
+func (testWrappedProblemAPI) Path() string {
+	return "/wrapped"
+}
+
+func (testWrappedProblemAPI) Output(ctx context.Context) (any, error) {
+	return nil, fmt.Errorf("create user: %w", NewError(http.StatusConflict, "user conflict", "user 1 already exists"))
+}
+
+type testPanicAPI struct {
+	MethodGet
+}
+
+func (testPanicAPI) Path() string {
+	return "/panic"
+}
+
+func (testPanicAPI) Output(ctx context.Context) (any, error) {
+	panic("boom")
+}
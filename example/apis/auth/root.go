@@ -0,0 +1,7 @@
+package auth
+
+import (
+	"github.com/zboyco/easygin"
+)
+
+var RouterRoot = easygin.NewRouterGroup("/auth")
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/zboyco/easygin"
+)
+
+func init() {
+	RouterRoot.RegisterAPI(&Token{})
+}
+
+type Token struct {
+	easygin.MethodPost `summary:"Issue access token"`
+	Body               ReqToken `in:"body" mime:"urlencoded"`
+}
+
+type ReqToken struct {
+	GrantType string `name:"grant_type" validate:"enum=password" desc:"Grant type"`
+	Username  string `name:"username" desc:"User name"`
+	Password  string `name:"password" desc:"Password"`
+	Scope     string `name:"scope,omitempty" desc:"Requested scope"`
+}
+
+type RespToken struct {
+	AccessToken string `json:"access_token" desc:"Access token"`
+	TokenType   string `json:"token_type" desc:"Token type"`
+	ExpiresIn   int    `json:"expires_in" desc:"Lifetime in seconds"`
+}
+
+func (Token) Path() string {
+	return "/token"
+}
+
+func (req *Token) Output(ctx context.Context) (any, error) {
+	if req.Body.Username != "admin" || req.Body.Password != "admin" {
+		return nil, easygin.NewError(401, "invalid credentials", "username or password is incorrect")
+	}
+	return &RespToken{
+		AccessToken: "token",
+		TokenType:   "Bearer",
+		ExpiresIn:   3600,
+	}, nil
+}
+
+func (Token) Responses() easygin.R {
+	return easygin.R{
+		200: &RespToken{},
+		401: &easygin.Error{},
+	}
+}
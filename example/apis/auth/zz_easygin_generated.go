@@ -0,0 +1,60 @@
+// Code generated by easygin; DO NOT EDIT.
+
+package auth
+
+import (
+
+	"github.com/gin-gonic/gin"
+	"github.com/zboyco/easygin"
+)
+
+func (r *Token) EasyGinBindParameters(c *gin.Context) error {
+	var verrs easygin.ValidationError
+
+	// 绑定urlencoded表单数据
+	if err := c.Request.ParseForm(); err != nil {
+		return err
+	}
+
+	// 遍历并绑定urlencoded字段
+
+	// 绑定表单参数 grant_type
+	func() {
+		formVal := c.PostForm("grant_type")
+		if formVal == "" {
+			verrs.Add("form", "grant_type", "missing required parameter", "")
+			return
+		}
+		r.Body.GrantType = string(formVal)
+		if err := easygin.ValidateValue(r.Body.GrantType, "enum=password"); err != nil {
+			verrs.Add("form", "grant_type", err.Error(), formVal)
+		}
+	}()
+	// 绑定表单参数 username
+	func() {
+		formVal := c.PostForm("username")
+		if formVal == "" {
+			verrs.Add("form", "username", "missing required parameter", "")
+			return
+		}
+		r.Body.Username = string(formVal)
+	}()
+	// 绑定表单参数 password
+	func() {
+		formVal := c.PostForm("password")
+		if formVal == "" {
+			verrs.Add("form", "password", "missing required parameter", "")
+			return
+		}
+		r.Body.Password = string(formVal)
+	}()
+	// 绑定表单参数 scope
+	func() {
+		formVal := c.PostForm("scope")
+		if formVal != "" {
+			r.Body.Scope = string(formVal)
+		}
+	}()
+	return verrs.Err()
+}
+
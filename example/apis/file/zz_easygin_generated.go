@@ -3,34 +3,41 @@
 package file
 
 import (
-	"errors"
 
 	"github.com/gin-gonic/gin"
+	"github.com/zboyco/easygin"
 )
 
 func (r *Download) EasyGinBindParameters(c *gin.Context) error {
-	return nil
+	var verrs easygin.ValidationError
+
+	return verrs.Err()
 }
 
 func (r *Image) EasyGinBindParameters(c *gin.Context) error {
-	return nil
+	var verrs easygin.ValidationError
+
+	return verrs.Err()
 }
 
 func (r *Redirect) EasyGinBindParameters(c *gin.Context) error {
+	var verrs easygin.ValidationError
+
 	// 绑定查询参数 url
-	{
+	func() {
 		queryVal := c.Query("url")
 		if queryVal == "" {
-			return errors.New("missing required parameter 'url' in query")
+			verrs.Add("query", "url", "missing required parameter", "")
+			return
 		}
-		if queryVal != "" {
-			r.Url = string(queryVal)
-		}
-	}
-	return nil
+		r.Url = string(queryVal)
+	}()
+	return verrs.Err()
 }
 
 func (r *UploadFile) EasyGinBindParameters(c *gin.Context) error {
+	var verrs easygin.ValidationError
+
 	// 实例化 Body
 	if r.Body == nil {
 		r.Body = &ReqUploadFile{}
@@ -44,27 +51,29 @@ func (r *UploadFile) EasyGinBindParameters(c *gin.Context) error {
 	// 遍历并绑定multipart字段
 
 	// 绑定表单参数 file
-	{
+	func() {
 		if file, ok := c.Request.MultipartForm.File["file"]; ok && len(file) > 0 {
 			r.Body.File = file[0]
 		} else {
-			return errors.New("missing required file 'file'")
+			verrs.Add("form", "file", "missing required file", "")
+			return
 		}
-	}
+	}()
 	// 绑定表单参数 images
-	{
+	func() {
 		if files, ok := c.Request.MultipartForm.File["images"]; ok {
 			r.Body.Images = files
 		} else {
 		}
-	}
+	}()
 	// 绑定表单参数 tags
-	{
+	func() {
 		r.Body.Tags = c.PostFormArray("tags")
 		if len(r.Body.Tags) == 0 {
-			return errors.New("missing required parameter 'tags' in form")
+			verrs.Add("form", "tags", "missing required parameter", "")
+			return
 		}
-	}
-	return nil
+	}()
+	return verrs.Err()
 }
 
@@ -3,6 +3,7 @@ package middleware
 import (
 	"context"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/zboyco/easygin"
 )
 
@@ -13,6 +14,8 @@ type MustAuth struct {
 	Authorization string `in:"header" name:"Authorization,omitempty" desc:"Bearer access_token"`
 	// Bearer access_token
 	AuthorizationInQuery string `in:"query" name:"authorization,omitempty" desc:"Bearer access_token"`
+	// access_token in session cookie
+	AuthorizationInCookie string `in:"cookie" name:"access_token,omitempty" desc:"access_token in session cookie"`
 }
 
 type UserInfo struct {
@@ -20,12 +23,21 @@ type UserInfo struct {
 	Name string
 }
 
-var ErrNotLogin = easygin.NewError(401, "用户未登录", "require authorization in header or query")
+var ErrNotLogin = easygin.NewError(401, "用户未登录", "require authorization in header, query or cookie")
 
+// Output 输出当前登录的用户信息，使用NewRouterGroup直接注册，API通过MustAuthFromContext获取
 func (req *MustAuth) Output(ctx context.Context) (any, error) {
+	return req.currentUser()
+}
+
+// currentUser 根据请求携带的access_token获取当前登录的用户信息
+func (req *MustAuth) currentUser() (*UserInfo, error) {
 	if req.AuthorizationInQuery != "" {
 		req.Authorization = req.AuthorizationInQuery
 	}
+	if req.Authorization == "" && req.AuthorizationInCookie != "" {
+		req.Authorization = "Bearer " + req.AuthorizationInCookie
+	}
 	if req.Authorization == "" {
 		return nil, ErrNotLogin
 	}
@@ -38,13 +50,28 @@ func (req *MustAuth) Output(ctx context.Context) (any, error) {
 	}, nil
 }
 
+// MustAuthUser MustAuth的类型化版本，使用easygin.NewMiddleware注册后API可以通过easygin.Dep[*UserInfo]获取用户信息
+//
+//	easygin.NewRouterGroup("/user", easygin.NewMiddleware[*middleware.UserInfo](&middleware.MustAuthUser{}))
+type MustAuthUser struct {
+	MustAuth
+}
+
+func (req *MustAuthUser) Output(ctx context.Context) (*UserInfo, error) {
+	return req.currentUser()
+}
+
+// OpenAPISecurity 使用MustAuth的API在OpenAPI文档中标记为需要bearerAuth认证
+func (MustAuth) OpenAPISecurity() openapi3.SecurityRequirements {
+	return easygin.SecurityRequirement("bearerAuth")
+}
+
 func (MustAuth) ContextKey() any {
 	return MustAuthContextKey(0)
 }
 
+// MustAuthFromContext 获取MustAuth存入上下文的用户信息，未登录时返回nil
 func MustAuthFromContext(c context.Context) *UserInfo {
-	if v := c.Value(MustAuthContextKey(0)); v != nil {
-		return v.(*UserInfo)
-	}
-	return nil
+	user, _ := easygin.FromContext[*UserInfo](c, &MustAuth{})
+	return user
 }
@@ -5,23 +5,33 @@ package middleware
 import (
 
 	"github.com/gin-gonic/gin"
+	"github.com/zboyco/easygin"
 )
 
-func (r *MustAuth) EasyGinBindParameters(c *gin.Context) error {
+func (r *MustAuthUser) EasyGinBindParameters(c *gin.Context) error {
+	var verrs easygin.ValidationError
+
 	// 绑定头部参数 Authorization
-	{
+	func() {
 		headerVal := c.GetHeader("Authorization")
 		if headerVal != "" {
 			r.Authorization = string(headerVal)
 		}
-	}
+	}()
 	// 绑定查询参数 authorization
-	{
+	func() {
 		queryVal := c.Query("authorization")
 		if queryVal != "" {
 			r.AuthorizationInQuery = string(queryVal)
 		}
-	}
-	return nil
+	}()
+	// 绑定Cookie参数 access_token
+	func() {
+		cookieVal, _ := c.Cookie("access_token")
+		if cookieVal != "" {
+			r.AuthorizationInCookie = string(cookieVal)
+		}
+	}()
+	return verrs.Err()
 }
 
@@ -2,6 +2,7 @@ package apis
 
 import (
 	"github.com/zboyco/easygin"
+	"github.com/zboyco/easygin/example/apis/auth"
 	"github.com/zboyco/easygin/example/apis/file"
 	"github.com/zboyco/easygin/example/apis/user"
 )
@@ -17,6 +18,7 @@ func init() {
 	RouterServer.RegisterAPI(easygin.OpenAPIRouter)                // 注册OpenAPI路由
 	RouterServer.RegisterAPI(easygin.NewSwaggerUIRouter(RouterServer.Path()))
 	{
+		RouterServer.RegisterGroup(auth.RouterRoot)
 		RouterServer.RegisterGroup(file.RouterRoot)
 		RouterServer.RegisterGroup(user.RouterRoot)
 	}
@@ -16,7 +16,7 @@ type CreateUser struct {
 }
 
 type ReqCreateUser struct {
-	Name      string `json:"name" desc:"User Name"`
+	Name      string `json:"name" validate:"min=1,max=32" desc:"User Name"`
 	Age       int    `json:"age" desc:"User Age"`
 	AgeString int    `json:"ageString,string" desc:"User Age"`
 }
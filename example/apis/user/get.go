@@ -2,9 +2,9 @@ package user
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/zboyco/easygin"
+	"github.com/zboyco/easygin/example/apis/middleware"
 )
 
 func init() {
@@ -18,6 +18,8 @@ type GetUser struct {
 	Names             []string `in:"query" name:"names" desc:"User Names"`
 	IDs               []uint64 `in:"query" name:"ids,omitempty" desc:"User IDs"`
 	Bools             []bool   `in:"query" name:"bools" desc:"User bool"`
+
+	CurrentUser easygin.Dep[*middleware.UserInfo]
 }
 
 func (GetUser) Path() string {
@@ -25,7 +27,6 @@ func (GetUser) Path() string {
 }
 
 func (req *GetUser) Output(ctx context.Context) (any, error) {
-	fmt.Println(req.Token)
 	if req.Token == "" {
 		return nil, easygin.NewError(401, "token is empty", "token is empty")
 	}
@@ -16,7 +16,7 @@ func init() {
 type ListUser struct {
 	easygin.MethodGet `summary:"Get user list" `
 	Name              string    `in:"query" name:"name,omitempty" desc:"User Name"`
-	AgeMin            int       `in:"query" name:"ageMin,omitempty" default:"18" desc:"User Min Age"`
+	AgeMin            int       `in:"query" name:"ageMin,omitempty" default:"18" validate:"min=0,max=150" desc:"User Min Age"`
 	StartTime         time.Time `in:"query" name:"startTime,omitempty" desc:"Start Time"`
 }
 
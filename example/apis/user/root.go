@@ -6,7 +6,7 @@ import (
 	"github.com/zboyco/easygin/example/apis/user/sub"
 )
 
-var RouterRoot = easygin.NewRouterGroup("/user", &middleware.MustAuth{})
+var RouterRoot = easygin.NewRouterGroup("/user", easygin.NewMiddleware[*middleware.UserInfo](&middleware.MustAuthUser{}))
 
 func init() {
 	RouterRoot.RegisterGroup(sub.RouterRoot)
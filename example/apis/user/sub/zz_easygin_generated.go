@@ -3,15 +3,17 @@
 package sub
 
 import (
-	"fmt"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/zboyco/easygin"
 )
 
 func (r *ListSub) EasyGinBindParameters(c *gin.Context) error {
+	var verrs easygin.ValidationError
+
 	// 绑定查询参数 size
-	{
+	func() {
 		queryVal := c.Query("size")
 		if queryVal == "0" {
 			queryVal = ""
@@ -22,13 +24,14 @@ func (r *ListSub) EasyGinBindParameters(c *gin.Context) error {
 		if queryVal != "" {
 			intVal, err := strconv.ParseInt(queryVal, 10, 64)
 			if err != nil {
-				return fmt.Errorf("invalid parameter 'size': %v", err.Error())
+				verrs.Add("query", "size", "invalid value", queryVal)
+				return
 			}
 			r.Size = int(intVal)
 		}
-	}
+	}()
 	// 绑定查询参数 offset
-	{
+	func() {
 		queryVal := c.Query("offset")
 		if queryVal == "0" {
 			queryVal = ""
@@ -39,11 +42,12 @@ func (r *ListSub) EasyGinBindParameters(c *gin.Context) error {
 		if queryVal != "" {
 			intVal, err := strconv.ParseInt(queryVal, 10, 64)
 			if err != nil {
-				return fmt.Errorf("invalid parameter 'offset': %v", err.Error())
+				verrs.Add("query", "offset", "invalid value", queryVal)
+				return
 			}
 			r.Offset = int(intVal)
 		}
-	}
-	return nil
+	}()
+	return verrs.Err()
 }
 
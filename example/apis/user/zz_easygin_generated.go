@@ -3,9 +3,6 @@
 package user
 
 import (
-	"encoding/json"
-	"errors"
-	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
@@ -16,107 +13,124 @@ import (
 )
 
 func (r *CreateUser) EasyGinBindParameters(c *gin.Context) error {
+	var verrs easygin.ValidationError
+
 	{
-		// 绑定JSON请求体
-		decoder := json.NewDecoder(c.Request.Body)
-		if err := decoder.Decode(&r.Body); err != nil {
+		// 根据Content-Type选择Codec绑定请求体
+		codec, err := easygin.RequestCodec(c, &r.Body)
+		if err != nil {
 			return err
 		}
+		if err := codec.Decode(c.Request.Body, &r.Body); err != nil {
+			verrs.Add("body", "", err.Error(), "")
+		} else {
+			if easygin.HandleBodyJsonOmitEmptyAndDefault() {
+				// 校验JSON必填字段和默认值
+				if err := verrs.Collect(easygin.ValidateJsonRequiredFields(reflect.ValueOf(&r.Body))); err != nil {
+					return err
+				}
+			}
 
-		if easygin.HandleBodyJsonOmitEmptyAndDefault() {
-			// 校验JSON必填字段和默认值
-			if err := easygin.ValidateJsonRequiredFields(reflect.ValueOf(&r.Body)); err != nil {
+			// 按照validate标签校验请求体字段
+			if err := easygin.ValidateJsonFields(reflect.ValueOf(&r.Body), &verrs); err != nil {
 				return err
 			}
 		}
 	}
-	return nil
+	return verrs.Err()
 }
 
 func (r *GetUser) EasyGinBindParameters(c *gin.Context) error {
+	var verrs easygin.ValidationError
+
 	// 绑定头部参数 Token
-	{
+	func() {
 		headerVal := c.GetHeader("Token")
 		if headerVal == "" {
-			return errors.New("missing required parameter 'Token' in header")
-		}
-		if headerVal != "" {
-			r.Token = string(headerVal)
+			verrs.Add("header", "Token", "missing required parameter", "")
+			return
 		}
-	}
+		r.Token = string(headerVal)
+	}()
 	// 绑定路径参数 id
-	{
+	func() {
 		pathVal := c.Param("id")
 		if pathVal == "0" {
 			pathVal = ""
 		}
 		if pathVal == "" {
-			return errors.New("missing required parameter 'id' in path")
+			verrs.Add("path", "id", "missing required parameter", "")
+			return
 		}
-		if pathVal != "" {
-			intVal, err := strconv.ParseInt(pathVal, 10, 64)
-			if err != nil {
-				return fmt.Errorf("invalid parameter 'id': %v", err.Error())
-			}
-			r.ID = int(intVal)
+		intVal, err := strconv.ParseInt(pathVal, 10, 64)
+		if err != nil {
+			verrs.Add("path", "id", "invalid value", pathVal)
+			return
 		}
-	}
+		r.ID = int(intVal)
+	}()
 	// 绑定查询参数 names
-	{
+	func() {
 		queryVals := c.QueryArray("names")
 		if len(queryVals) == 0 {
-			return errors.New("missing required parameter 'names' in query")
+			verrs.Add("query", "names", "missing required parameter", "")
+			return
 		}
 		if len(queryVals) > 0 {
 			r.Names = queryVals
 		}
-	}
+	}()
 	// 绑定查询参数 ids
-	{
+	func() {
 		queryVals := c.QueryArray("ids")
 		if len(queryVals) > 0 {
 			convertedVals := make([]uint64, 0, len(queryVals))
 			for _, val := range queryVals {
 				parsedVal, err := strconv.ParseUint(val, 10, 64)
 				if err != nil {
-					return fmt.Errorf("invalid parameter 'ids': %v", err.Error())
+					verrs.Add("query", "ids", "invalid value", val)
+					return
 				}
 				convertedVals = append(convertedVals, uint64(parsedVal))
 			}
 			r.IDs = convertedVals
 		}
-	}
+	}()
 	// 绑定查询参数 bools
-	{
+	func() {
 		queryVals := c.QueryArray("bools")
 		if len(queryVals) == 0 {
-			return errors.New("missing required parameter 'bools' in query")
+			verrs.Add("query", "bools", "missing required parameter", "")
+			return
 		}
 		if len(queryVals) > 0 {
 			convertedVals := make([]bool, 0, len(queryVals))
 			for _, val := range queryVals {
 				boolVal, err := strconv.ParseBool(val)
 				if err != nil {
-					return fmt.Errorf("invalid parameter 'bools': %v", err.Error())
+					verrs.Add("query", "bools", "invalid value", val)
+					return
 				}
 				convertedVals = append(convertedVals, boolVal)
 			}
 			r.Bools = convertedVals
 		}
-	}
-	return nil
+	}()
+	return verrs.Err()
 }
 
 func (r *ListUser) EasyGinBindParameters(c *gin.Context) error {
+	var verrs easygin.ValidationError
+
 	// 绑定查询参数 name
-	{
+	func() {
 		queryVal := c.Query("name")
 		if queryVal != "" {
 			r.Name = string(queryVal)
 		}
-	}
+	}()
 	// 绑定查询参数 ageMin
-	{
+	func() {
 		queryVal := c.Query("ageMin")
 		if queryVal == "0" {
 			queryVal = ""
@@ -127,13 +141,17 @@ func (r *ListUser) EasyGinBindParameters(c *gin.Context) error {
 		if queryVal != "" {
 			intVal, err := strconv.ParseInt(queryVal, 10, 64)
 			if err != nil {
-				return fmt.Errorf("invalid parameter 'ageMin': %v", err.Error())
+				verrs.Add("query", "ageMin", "invalid value", queryVal)
+				return
 			}
 			r.AgeMin = int(intVal)
+			if err := easygin.ValidateValue(r.AgeMin, "min=0,max=150"); err != nil {
+				verrs.Add("query", "ageMin", err.Error(), queryVal)
+			}
 		}
-	}
+	}()
 	// 绑定查询参数 startTime
-	{
+	func() {
 		queryVal := c.Query("startTime")
 		if strings.HasPrefix(queryVal, "0000-00-00T00:00:00") {
 			queryVal = ""
@@ -141,13 +159,14 @@ func (r *ListUser) EasyGinBindParameters(c *gin.Context) error {
 		if queryVal != "" {
 			t, err := time.Parse(time.RFC3339, queryVal)
 			if err != nil {
-				return fmt.Errorf("invalid time format for parameter 'startTime': %v", err.Error())
+				verrs.Add("query", "startTime", "invalid value", queryVal)
+				return
 			}
 			if !t.IsZero() {
 				r.StartTime = t
 			}
 		}
-	}
-	return nil
+	}()
+	return verrs.Err()
 }
 
@@ -0,0 +1,156 @@
+// Code generated by easygin; DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+
+	"github.com/zboyco/easygin"
+	"github.com/zboyco/easygin/example/apis/auth"
+	"github.com/zboyco/easygin/example/apis/file"
+	"github.com/zboyco/easygin/example/apis/user"
+	"github.com/zboyco/easygin/example/apis/user/sub"
+)
+
+// Client 由easygin根据路由组生成的HTTP客户端
+type Client struct {
+	*easygin.Client
+}
+
+// NewClient 创建客户端，baseURL为服务地址，如http://127.0.0.1:8080
+func NewClient(baseURL string) *Client {
+	return &Client{Client: easygin.NewClient(baseURL)}
+}
+
+// Token Issue access token
+// POST /server/auth/token
+func (c *Client) Token(ctx context.Context, req *auth.Token) (*auth.RespToken, error) {
+	r := easygin.NewClientRequest(http.MethodPost, "/server/auth/token")
+	form := url.Values{}
+	form.Add("grant_type", easygin.FormatParameter(req.Body.GrantType))
+	form.Add("username", easygin.FormatParameter(req.Body.Username))
+	form.Add("password", easygin.FormatParameter(req.Body.Password))
+	if !easygin.IsZeroParameter(req.Body.Scope) {
+		form.Add("scope", easygin.FormatParameter(req.Body.Scope))
+	}
+	r.SetURLEncodedBody(form)
+	resp := new(auth.RespToken)
+	r.Responses = map[int]any{200: resp}
+	if err := c.Do(ctx, r); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Download download file
+// GET /server/file/download
+func (c *Client) Download(ctx context.Context, req *file.Download) error {
+	r := easygin.NewClientRequest(http.MethodGet, "/server/file/download")
+	return c.Do(ctx, r)
+}
+
+// Image image
+// GET /server/file/image
+func (c *Client) Image(ctx context.Context, req *file.Image) error {
+	r := easygin.NewClientRequest(http.MethodGet, "/server/file/image")
+	return c.Do(ctx, r)
+}
+
+// Redirect Redirect
+// GET /server/file/redirect
+func (c *Client) Redirect(ctx context.Context, req *file.Redirect) error {
+	r := easygin.NewClientRequest(http.MethodGet, "/server/file/redirect")
+	r.Query.Add("url", easygin.FormatParameter(req.Url))
+	return c.Do(ctx, r)
+}
+
+// UploadFile Upload file
+// POST /server/file/upload
+func (c *Client) UploadFile(ctx context.Context, req *file.UploadFile) error {
+	r := easygin.NewClientRequest(http.MethodPost, "/server/file/upload")
+	form := url.Values{}
+	files := map[string][]*multipart.FileHeader{}
+	if req.Body != nil {
+		if req.Body.File != nil {
+			files["file"] = append(files["file"], req.Body.File)
+		}
+		files["images"] = append(files["images"], req.Body.Images...)
+		for _, v := range req.Body.Tags {
+			form.Add("tags", easygin.FormatParameter(v))
+		}
+	}
+	if err := r.SetMultipartBody(form, files); err != nil {
+		return err
+	}
+	return c.Do(ctx, r)
+}
+
+// CreateUser Create user
+// POST /server/user
+func (c *Client) CreateUser(ctx context.Context, req *user.CreateUser) error {
+	r := easygin.NewClientRequest(http.MethodPost, "/server/user")
+	if err := r.SetJSONBody(req.Body); err != nil {
+		return err
+	}
+	return c.Do(ctx, r)
+}
+
+// GetUser Get user info
+// GET /server/user/:id
+func (c *Client) GetUser(ctx context.Context, req *user.GetUser) (*user.RespGetUser, error) {
+	r := easygin.NewClientRequest(http.MethodGet, "/server/user/:id")
+	r.Header.Add("Token", easygin.FormatParameter(req.Token))
+	r.SetPathParam("id", req.ID)
+	for _, v := range req.Names {
+		r.Query.Add("names", easygin.FormatParameter(v))
+	}
+	for _, v := range req.IDs {
+		r.Query.Add("ids", easygin.FormatParameter(v))
+	}
+	for _, v := range req.Bools {
+		r.Query.Add("bools", easygin.FormatParameter(v))
+	}
+	resp := new(user.RespGetUser)
+	r.Responses = map[int]any{200: resp}
+	if err := c.Do(ctx, r); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListUser Get user list
+// GET /server/user
+func (c *Client) ListUser(ctx context.Context, req *user.ListUser) ([]user.RespGetUser, error) {
+	r := easygin.NewClientRequest(http.MethodGet, "/server/user")
+	if !easygin.IsZeroParameter(req.Name) {
+		r.Query.Add("name", easygin.FormatParameter(req.Name))
+	}
+	if !easygin.IsZeroParameter(req.AgeMin) {
+		r.Query.Add("ageMin", easygin.FormatParameter(req.AgeMin))
+	}
+	if !easygin.IsZeroParameter(req.StartTime) {
+		r.Query.Add("startTime", easygin.FormatParameter(req.StartTime))
+	}
+	var resp []user.RespGetUser
+	r.Responses = map[int]any{200: &resp}
+	if err := c.Do(ctx, r); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListSub Get sub list
+// GET /server/user/sub/list
+func (c *Client) ListSub(ctx context.Context, req *sub.ListSub) error {
+	r := easygin.NewClientRequest(http.MethodGet, "/server/user/sub/list")
+	if !easygin.IsZeroParameter(req.Size) {
+		r.Query.Add("size", easygin.FormatParameter(req.Size))
+	}
+	if !easygin.IsZeroParameter(req.Offset) {
+		r.Query.Add("offset", easygin.FormatParameter(req.Offset))
+	}
+	return c.Do(ctx, r)
+}
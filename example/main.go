@@ -1,6 +1,7 @@
 package main
 
 import (
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/zboyco/easygin"
 	"github.com/zboyco/easygin/example/apis"
 )
@@ -15,6 +16,15 @@ func main() {
 	// 设置日志等级为DebugLevel
 	easygin.SetLogLevel(easygin.DebugLevel)
 
-	srv := easygin.NewServer(serverName, ":80", true)
+	// 启动时生成OpenAPI文档，不依赖当前目录下的openapi.json
+	srv := easygin.NewServer(serverName, ":80", true).
+		WithOpenAPI().
+		WithOpenAPIOptions(easygin.OpenAPIOptions{
+			Title:   "Example API",
+			Version: "1.0.0",
+			SecuritySchemes: openapi3.SecuritySchemes{
+				"bearerAuth": easygin.NewBearerSecurityScheme("JWT"),
+			},
+		})
 	srv.Run(apis.RouterRoot)
 }
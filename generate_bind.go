@@ -46,7 +46,7 @@ func GenerateParametersBindFunction(groups ...*RouterGroup) error {
 				continue
 			}
 
-			apiType := reflect.TypeOf(api)
+			apiType := reflect.TypeOf(handlerValue(api))
 			if apiType.Kind() == reflect.Ptr {
 				apiType = apiType.Elem()
 			}
@@ -88,11 +88,14 @@ func generateBindParametersMethod(t reflect.Type, currentPkgPath string) string
 	// 写入方法签名
 	builder.WriteString(fmt.Sprintf("func (r *%s) EasyGinBindParameters(c *gin.Context) error {\n", t.Name()))
 
+	// 收集所有字段的绑定和校验错误，处理完所有字段后统一返回
+	builder.WriteString("\tvar verrs easygin.ValidationError\n\n")
+
 	// 处理所有字段
 	processAllFields(&builder, t, "r", currentPkgPath)
 
-	// 返回nil
-	builder.WriteString("\treturn nil\n")
+	// 返回收集到的字段错误
+	builder.WriteString("\treturn verrs.Err()\n")
 	builder.WriteString("}")
 
 	return builder.String()
@@ -137,6 +140,8 @@ func processAllFields(builder *strings.Builder, t reflect.Type, prefix string, c
 					generateQueryBinding(builder, fieldName, name, field)
 				case "header":
 					generateHeaderBinding(builder, fieldName, name, field)
+				case "cookie":
+					generateCookieBinding(builder, fieldName, name, field)
 				case "body":
 					generateBodyBinding(builder, fieldName, field, currentPkgPath)
 				}
@@ -167,6 +172,8 @@ func processAllFields(builder *strings.Builder, t reflect.Type, prefix string, c
 			generateQueryBinding(builder, fieldName, name, field)
 		case "header":
 			generateHeaderBinding(builder, fieldName, name, field)
+		case "cookie":
+			generateCookieBinding(builder, fieldName, name, field)
 		case "body":
 			generateBodyBinding(builder, fieldName, field, currentPkgPath)
 		}
@@ -176,7 +183,7 @@ func processAllFields(builder *strings.Builder, t reflect.Type, prefix string, c
 // generatePathBinding 生成路径参数绑定代码
 func generatePathBinding(builder *strings.Builder, fieldName, paramName string, field reflect.StructField) {
 	builder.WriteString(fmt.Sprintf("\t// 绑定路径参数 %s\n", paramName))
-	builder.WriteString("\t{\n") // 添加代码块开始
+	builder.WriteString("\tfunc() {\n") // 添加闭包开始，绑定失败时记录字段错误并返回
 	builder.WriteString(fmt.Sprintf("\t\tpathVal := c.Param(\"%s\")\n", paramName))
 
 	// 根据字段类型添加零值检查
@@ -194,7 +201,7 @@ func generatePathBinding(builder *strings.Builder, fieldName, paramName string,
 
 	if !isOmitempty {
 		builder.WriteString("\t\tif pathVal == \"\" {\n")
-		builder.WriteString(fmt.Sprintf("\t\t\treturn errors.New(\"missing required parameter '%s' in path\")\n", paramName))
+		writeFieldError(builder, "\t\t\t", "path", paramName, reasonMissingParameter, "\"\"")
 		builder.WriteString("\t\t}\n")
 	} else {
 		// 处理默认值
@@ -211,20 +218,14 @@ func generatePathBinding(builder *strings.Builder, fieldName, paramName string,
 		}
 	}
 
-	builder.WriteString("\t\tif pathVal != \"\" {\n")
-	if field.Type.Name() != "" {
-		generateTypeConversion(builder, fieldName, "pathVal", paramName, field.Type, "\t\t\t")
-	} else {
-		generateValueConversion(builder, fieldName, "pathVal", paramName, field.Type)
-	}
-	builder.WriteString("\t\t}\n")
-	builder.WriteString("\t}\n") // 添加代码块结束
+	generateParameterConversion(builder, fieldName, "pathVal", "path", paramName, field, !isOmitempty)
+	builder.WriteString("\t}()\n") // 添加闭包结束
 }
 
 // generateQueryBinding 生成查询参数绑定代码
 func generateQueryBinding(builder *strings.Builder, fieldName, paramName string, field reflect.StructField) {
 	builder.WriteString(fmt.Sprintf("\t// 绑定查询参数 %s\n", paramName))
-	builder.WriteString("\t{\n") // 添加代码块开始
+	builder.WriteString("\tfunc() {\n") // 添加闭包开始，绑定失败时记录字段错误并返回
 	fieldType := field.Type
 	isSlicePtr := false
 	if fieldType.Kind() == reflect.Ptr && fieldType.Elem().Kind() == reflect.Slice {
@@ -244,11 +245,16 @@ func generateQueryBinding(builder *strings.Builder, fieldName, paramName string,
 
 	if fieldType.Kind() == reflect.Slice {
 		if generateQuerySliceBinding(builder, fieldName, paramName, fieldType, isSlicePtr, isOmitempty) {
-			builder.WriteString("\t}\n")
+			if validateTag(field) != "" {
+				builder.WriteString("\t\tif len(queryVals) > 0 {\n")
+				generateValidateCall(builder, fieldName, "query", paramName, field, "strings.Join(queryVals, \",\")", "\t\t\t")
+				builder.WriteString("\t\t}\n")
+			}
+			builder.WriteString("\t}()\n")
 			return
 		}
-		builder.WriteString(fmt.Sprintf("\t\treturn errors.New(\"unsupported slice parameter type '%s' in query\")\n", fieldType.String()))
-		builder.WriteString("\t}\n")
+		writeFieldError(builder, "\t\t", "query", paramName, "unsupported parameter type: "+fieldType.String(), "\"\"")
+		builder.WriteString("\t}()\n")
 		return
 	}
 
@@ -259,7 +265,7 @@ func generateQueryBinding(builder *strings.Builder, fieldName, paramName string,
 
 	if !isOmitempty {
 		builder.WriteString("\t\tif queryVal == \"\" {\n")
-		builder.WriteString(fmt.Sprintf("\t\t\treturn errors.New(\"missing required parameter '%s' in query\")\n", paramName))
+		writeFieldError(builder, "\t\t\t", "query", paramName, reasonMissingParameter, "\"\"")
 		builder.WriteString("\t\t}\n")
 	} else {
 		// 处理默认值
@@ -275,14 +281,8 @@ func generateQueryBinding(builder *strings.Builder, fieldName, paramName string,
 		}
 	}
 
-	builder.WriteString("\t\tif queryVal != \"\" {\n")
-	if field.Type.Name() != "" {
-		generateTypeConversion(builder, fieldName, "queryVal", paramName, field.Type, "\t\t\t")
-	} else {
-		generateValueConversion(builder, fieldName, "queryVal", paramName, field.Type)
-	}
-	builder.WriteString("\t\t}\n")
-	builder.WriteString("\t}\n")
+	generateParameterConversion(builder, fieldName, "queryVal", "query", paramName, field, !isOmitempty)
+	builder.WriteString("\t}()\n") // 添加闭包结束
 }
 
 // generateQuerySliceBinding 生成查询参数切片绑定代码
@@ -307,7 +307,7 @@ func generateQuerySliceBinding(builder *strings.Builder, fieldName, paramName st
 	builder.WriteString(fmt.Sprintf("\t\tqueryVals := c.QueryArray(\"%s\")\n", paramName))
 	if !isOmitempty {
 		builder.WriteString("\t\tif len(queryVals) == 0 {\n")
-		builder.WriteString(fmt.Sprintf("\t\t\treturn errors.New(\"missing required parameter '%s' in query\")\n", paramName))
+		writeFieldError(builder, "\t\t\t", "query", paramName, reasonMissingParameter, "\"\"")
 		builder.WriteString("\t\t}\n")
 	}
 	builder.WriteString("\t\tif len(queryVals) > 0 {\n")
@@ -334,7 +334,7 @@ func generateQuerySliceBinding(builder *strings.Builder, fieldName, paramName st
 		builder.WriteString("\t\t\tfor _, val := range queryVals {\n")
 		builder.WriteString("\t\t\t\tparsedVal, err := strconv.ParseInt(val, 10, 64)\n")
 		builder.WriteString("\t\t\t\tif err != nil {\n")
-		builder.WriteString(fmt.Sprintf("\t\t\t\t\treturn fmt.Errorf(\"invalid parameter '%s': %%v\", err.Error())\n", paramName))
+		writeFieldError(builder, "\t\t\t\t\t", "query", paramName, reasonInvalidValue, "val")
 		builder.WriteString("\t\t\t\t}\n")
 		if isElemPtr {
 			builder.WriteString(fmt.Sprintf("\t\t\t\tvalCopy := %s(parsedVal)\n", baseType.String()))
@@ -349,7 +349,7 @@ func generateQuerySliceBinding(builder *strings.Builder, fieldName, paramName st
 		builder.WriteString("\t\t\tfor _, val := range queryVals {\n")
 		builder.WriteString("\t\t\t\tparsedVal, err := strconv.ParseUint(val, 10, 64)\n")
 		builder.WriteString("\t\t\t\tif err != nil {\n")
-		builder.WriteString(fmt.Sprintf("\t\t\t\t\treturn fmt.Errorf(\"invalid parameter '%s': %%v\", err.Error())\n", paramName))
+		writeFieldError(builder, "\t\t\t\t\t", "query", paramName, reasonInvalidValue, "val")
 		builder.WriteString("\t\t\t\t}\n")
 		if isElemPtr {
 			builder.WriteString(fmt.Sprintf("\t\t\t\tvalCopy := %s(parsedVal)\n", baseType.String()))
@@ -364,7 +364,7 @@ func generateQuerySliceBinding(builder *strings.Builder, fieldName, paramName st
 		builder.WriteString("\t\t\tfor _, val := range queryVals {\n")
 		builder.WriteString("\t\t\t\tfloatVal, err := strconv.ParseFloat(val, 64)\n")
 		builder.WriteString("\t\t\t\tif err != nil {\n")
-		builder.WriteString(fmt.Sprintf("\t\t\t\t\treturn fmt.Errorf(\"invalid parameter '%s': %%v\", err.Error())\n", paramName))
+		writeFieldError(builder, "\t\t\t\t\t", "query", paramName, reasonInvalidValue, "val")
 		builder.WriteString("\t\t\t\t}\n")
 		if isElemPtr {
 			builder.WriteString(fmt.Sprintf("\t\t\t\tvalCopy := %s(floatVal)\n", baseType.String()))
@@ -379,7 +379,7 @@ func generateQuerySliceBinding(builder *strings.Builder, fieldName, paramName st
 		builder.WriteString("\t\t\tfor _, val := range queryVals {\n")
 		builder.WriteString("\t\t\t\tboolVal, err := strconv.ParseBool(val)\n")
 		builder.WriteString("\t\t\t\tif err != nil {\n")
-		builder.WriteString(fmt.Sprintf("\t\t\t\t\treturn fmt.Errorf(\"invalid parameter '%s': %%v\", err.Error())\n", paramName))
+		writeFieldError(builder, "\t\t\t\t\t", "query", paramName, reasonInvalidValue, "val")
 		builder.WriteString("\t\t\t\t}\n")
 		if isElemPtr {
 			builder.WriteString("\t\t\t\tvalCopy := boolVal\n")
@@ -434,7 +434,7 @@ func isFloatKind(kind reflect.Kind) bool {
 // generateHeaderBinding 生成头部参数绑定代码
 func generateHeaderBinding(builder *strings.Builder, fieldName, paramName string, field reflect.StructField) {
 	builder.WriteString(fmt.Sprintf("\t// 绑定头部参数 %s\n", paramName))
-	builder.WriteString("\t{\n") // 添加代码块开始
+	builder.WriteString("\tfunc() {\n") // 添加闭包开始，绑定失败时记录字段错误并返回
 	builder.WriteString(fmt.Sprintf("\t\theaderVal := c.GetHeader(\"%s\")\n", paramName))
 
 	// 根据字段类型添加零值检查
@@ -452,7 +452,7 @@ func generateHeaderBinding(builder *strings.Builder, fieldName, paramName string
 
 	if !isOmitempty {
 		builder.WriteString("\t\tif headerVal == \"\" {\n")
-		builder.WriteString(fmt.Sprintf("\t\t\treturn errors.New(\"missing required parameter '%s' in header\")\n", paramName))
+		writeFieldError(builder, "\t\t\t", "header", paramName, reasonMissingParameter, "\"\"")
 		builder.WriteString("\t\t}\n")
 	} else {
 		// 处理默认值
@@ -468,14 +468,49 @@ func generateHeaderBinding(builder *strings.Builder, fieldName, paramName string
 		}
 	}
 
-	builder.WriteString("\t\tif headerVal != \"\" {\n")
-	if field.Type.Name() != "" {
-		generateTypeConversion(builder, fieldName, "headerVal", paramName, field.Type, "\t\t\t")
+	generateParameterConversion(builder, fieldName, "headerVal", "header", paramName, field, !isOmitempty)
+	builder.WriteString("\t}()\n") // 添加闭包结束
+}
+
+// generateCookieBinding 生成Cookie参数绑定代码
+func generateCookieBinding(builder *strings.Builder, fieldName, paramName string, field reflect.StructField) {
+	builder.WriteString(fmt.Sprintf("\t// 绑定Cookie参数 %s\n", paramName))
+	builder.WriteString("\tfunc() {\n") // 添加闭包开始，绑定失败时记录字段错误并返回
+	builder.WriteString(fmt.Sprintf("\t\tcookieVal, _ := c.Cookie(\"%s\")\n", paramName))
+
+	// 根据字段类型添加零值检查
+	addZeroValueCheck(builder, "cookieVal", field.Type)
+
+	// 检查是否必填
+	tagNames := strings.Split(field.Tag.Get("name"), ",")
+	isOmitempty := false
+	for _, tag := range tagNames {
+		if tag == "omitempty" {
+			isOmitempty = true
+			break
+		}
+	}
+
+	if !isOmitempty {
+		builder.WriteString("\t\tif cookieVal == \"\" {\n")
+		writeFieldError(builder, "\t\t\t", "cookie", paramName, reasonMissingParameter, "\"\"")
+		builder.WriteString("\t\t}\n")
 	} else {
-		generateValueConversion(builder, fieldName, "headerVal", paramName, field.Type)
+		// 处理默认值
+		defaultValue := field.Tag.Get("default")
+		if defaultValue != "" {
+			if isDefaultValueValid(defaultValue, field.Type) {
+				builder.WriteString("\t\tif cookieVal == \"\" {\n")
+				builder.WriteString(fmt.Sprintf("\t\t\tcookieVal = \"%s\"\n", defaultValue))
+				builder.WriteString("\t\t}\n")
+			} else {
+				panic(fmt.Sprintf("default value '%s' does not match the field type for parameter '%s'", defaultValue, paramName))
+			}
+		}
 	}
-	builder.WriteString("\t\t}\n")
-	builder.WriteString("\t}\n")
+
+	generateParameterConversion(builder, fieldName, "cookieVal", "cookie", paramName, field, !isOmitempty)
+	builder.WriteString("\t}()\n") // 添加闭包结束
 }
 
 // generateBodyBinding 生成请求体绑定代码
@@ -537,14 +572,19 @@ func generateBodyBinding(builder *strings.Builder, fieldName string, field refle
 		}
 	}
 
-	if mime == "multipart" {
-		builder.WriteString("\t// 绑定multipart表单数据\n")
-		builder.WriteString("\tif err := c.Request.ParseMultipartForm(1 << 30); err != nil {\n")
+	if mime == "multipart" || mime == "urlencoded" {
+		if mime == "multipart" {
+			builder.WriteString("\t// 绑定multipart表单数据\n")
+			builder.WriteString("\tif err := c.Request.ParseMultipartForm(1 << 30); err != nil {\n")
+		} else {
+			builder.WriteString("\t// 绑定urlencoded表单数据\n")
+			builder.WriteString("\tif err := c.Request.ParseForm(); err != nil {\n")
+		}
 		builder.WriteString("\t\treturn err\n")
 		builder.WriteString("\t}\n")
 
 		// 遍历字段并生成绑定代码
-		builder.WriteString("\n\t// 遍历并绑定multipart字段\n\n")
+		builder.WriteString(fmt.Sprintf("\n\t// 遍历并绑定%s字段\n\n", mime))
 		structType := field.Type
 		if structType.Kind() == reflect.Ptr {
 			structType = structType.Elem()
@@ -557,33 +597,49 @@ func generateBodyBinding(builder *strings.Builder, fieldName string, field refle
 			}
 			name = strings.Split(name, ",")[0]
 
+			// urlencoded表单不支持上传文件
+			if mime == "urlencoded" && isFileField(subField.Type) {
+				panic(fmt.Sprintf("file field '%s' is not supported in `mime:\"urlencoded\"` body", name))
+			}
+
 			// 使用 generateFormBinding 处理表单字段
 			generateFormBinding(builder, fmt.Sprintf("%s.%s", fieldName, subField.Name), name, subField)
 		}
 	} else {
 		builder.WriteString("\t{\n") // 添加代码块开始
-		builder.WriteString("\t\t// 绑定JSON请求体\n")
-		builder.WriteString("\t\tdecoder := json.NewDecoder(c.Request.Body)\n")
-
+		builder.WriteString("\t\t// 根据Content-Type选择Codec绑定请求体\n")
 		// 根据字段类型是否为指针决定是否添加&符号
-		if field.Type.Kind() == reflect.Ptr {
-			builder.WriteString(fmt.Sprintf("\t\tif err := decoder.Decode(%s); err != nil {\n", fieldName))
-		} else {
-			builder.WriteString(fmt.Sprintf("\t\tif err := decoder.Decode(&%s); err != nil {\n", fieldName))
+		target := fieldName
+		if field.Type.Kind() != reflect.Ptr {
+			target = "&" + fieldName
 		}
-
+		builder.WriteString(fmt.Sprintf("\t\tcodec, err := easygin.RequestCodec(c, %s%s)\n", target, codecArgs(mime)))
+		builder.WriteString("\t\tif err != nil {\n")
 		builder.WriteString("\t\t\treturn err\n")
 		builder.WriteString("\t\t}\n")
+		builder.WriteString(fmt.Sprintf("\t\tif err := codec.Decode(c.Request.Body, %s); err != nil {\n", target))
+
+		builder.WriteString("\t\t\tverrs.Add(\"body\", \"\", err.Error(), \"\")\n")
+		builder.WriteString("\t\t} else {\n")
 
 		// TODO 待优化项，目前使用的是ValidateJsonRequiredFields反射校验，性能较差
 		// 后续考虑根据结构体生成对应的校验代码，性能更好
 
-		// 使用ValidateJsonRequiredFields进行校验
-		builder.WriteString("\n\t\tif easygin.HandleBodyJsonOmitEmptyAndDefault() {\n")
-		builder.WriteString("\t\t\t// 校验JSON必填字段和默认值\n")
-		builder.WriteString(fmt.Sprintf("\t\t\tif err := easygin.ValidateJsonRequiredFields(reflect.ValueOf(&%s)); err != nil {\n", fieldName))
-		builder.WriteString("\t\t\t\treturn err\n")
+		// 使用ValidateJsonRequiredFields进行校验，缺失的必填字段收集到verrs中
+		builder.WriteString("\t\t\tif easygin.HandleBodyJsonOmitEmptyAndDefault() {\n")
+		builder.WriteString("\t\t\t\t// 校验JSON必填字段和默认值\n")
+		builder.WriteString(fmt.Sprintf("\t\t\t\tif err := verrs.Collect(easygin.ValidateJsonRequiredFields(reflect.ValueOf(&%s))); err != nil {\n", fieldName))
+		builder.WriteString("\t\t\t\t\treturn err\n")
+		builder.WriteString("\t\t\t\t}\n")
 		builder.WriteString("\t\t\t}\n")
+
+		// 存在validate标签时，按照标签校验请求体字段
+		if typeHasValidateRules(field.Type) {
+			builder.WriteString("\n\t\t\t// 按照validate标签校验请求体字段\n")
+			builder.WriteString(fmt.Sprintf("\t\t\tif err := easygin.ValidateJsonFields(reflect.ValueOf(&%s), &verrs); err != nil {\n", fieldName))
+			builder.WriteString("\t\t\t\treturn err\n")
+			builder.WriteString("\t\t\t}\n")
+		}
 		builder.WriteString("\t\t}\n")
 
 		builder.WriteString("\t}\n") // 添加代码块结束
@@ -592,7 +648,7 @@ func generateBodyBinding(builder *strings.Builder, fieldName string, field refle
 
 func generateFormBinding(builder *strings.Builder, fieldName, paramName string, field reflect.StructField) {
 	builder.WriteString(fmt.Sprintf("\t// 绑定表单参数 %s\n", paramName))
-	builder.WriteString("\t{\n") // 添加代码块开始
+	builder.WriteString("\tfunc() {\n") // 添加闭包开始，绑定失败时记录字段错误并返回
 
 	// 检查是否可为空
 	tagNames := strings.Split(field.Tag.Get("name"), ",")
@@ -614,7 +670,7 @@ func generateFormBinding(builder *strings.Builder, fieldName, paramName string,
 		builder.WriteString(fmt.Sprintf("\t\t\t%s = file[0]\n", fieldName))
 		builder.WriteString("\t\t} else {\n")
 		if !isOmitempty {
-			builder.WriteString(fmt.Sprintf("\t\t\treturn errors.New(\"missing required file '%s'\")\n", paramName))
+			writeFieldError(builder, "\t\t\t", "form", paramName, reasonMissingFile, "\"\"")
 		}
 		builder.WriteString("\t\t}\n")
 	} else if field.Type.Kind() == reflect.Slice && field.Type.Elem().String() == "*multipart.FileHeader" {
@@ -626,7 +682,7 @@ func generateFormBinding(builder *strings.Builder, fieldName, paramName string,
 		builder.WriteString(fmt.Sprintf("\t\t\t%s = files\n", fieldName))
 		builder.WriteString("\t\t} else {\n")
 		if !isOmitempty {
-			builder.WriteString(fmt.Sprintf("\t\t\treturn errors.New(\"missing required files '%s'\")\n", paramName))
+			writeFieldError(builder, "\t\t\t", "form", paramName, reasonMissingFile, "\"\"")
 		}
 		builder.WriteString("\t\t}\n")
 	} else if field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.String {
@@ -634,7 +690,7 @@ func generateFormBinding(builder *strings.Builder, fieldName, paramName string,
 		builder.WriteString(fmt.Sprintf("\t\t%s = c.PostFormArray(\"%s\")\n", fieldName, paramName))
 		if !isOmitempty {
 			builder.WriteString(fmt.Sprintf("\t\tif len(%s) == 0 {\n", fieldName))
-			builder.WriteString(fmt.Sprintf("\t\t\treturn errors.New(\"missing required parameter '%s' in form\")\n", paramName))
+			writeFieldError(builder, "\t\t\t", "form", paramName, reasonMissingParameter, "\"\"")
 			builder.WriteString("\t\t}\n")
 		}
 	} else if field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Slice && field.Type.Elem().Elem().Kind() == reflect.String {
@@ -642,7 +698,7 @@ func generateFormBinding(builder *strings.Builder, fieldName, paramName string,
 		builder.WriteString(fmt.Sprintf("\t\tvalues := c.PostFormArray(\"%s\")\n", paramName))
 		if !isOmitempty {
 			builder.WriteString("\t\tif len(values) == 0 {\n")
-			builder.WriteString(fmt.Sprintf("\t\t\treturn errors.New(\"missing required parameter '%s' in form\")\n", paramName))
+			writeFieldError(builder, "\t\t\t", "form", paramName, reasonMissingParameter, "\"\"")
 			builder.WriteString("\t\t}\n")
 		}
 		builder.WriteString("\t\tif len(values) > 0 {\n")
@@ -657,7 +713,7 @@ func generateFormBinding(builder *strings.Builder, fieldName, paramName string,
 
 		if !isOmitempty {
 			builder.WriteString("\t\tif formVal == \"\" {\n")
-			builder.WriteString(fmt.Sprintf("\t\t\treturn errors.New(\"missing required parameter '%s' in form\")\n", paramName))
+			writeFieldError(builder, "\t\t\t", "form", paramName, reasonMissingParameter, "\"\"")
 			builder.WriteString("\t\t}\n")
 		} else {
 			// 处理默认值
@@ -673,42 +729,109 @@ func generateFormBinding(builder *strings.Builder, fieldName, paramName string,
 			}
 		}
 
-		builder.WriteString("\t\tif formVal != \"\" {\n")
-		if field.Type.Name() != "" {
-			generateTypeConversion(builder, fieldName, "formVal", paramName, field.Type, "\t\t\t")
-		} else {
-			generateValueConversion(builder, fieldName, "formVal", paramName, field.Type)
-		}
+		generateParameterConversion(builder, fieldName, "formVal", "form", paramName, field, !isOmitempty)
+	}
+	builder.WriteString("\t}()\n") // 添加闭包结束
+}
+
+// generateParameterConversion 生成参数值的类型转换和校验代码
+// 必填参数缺失时已经记录字段错误并返回，不再判断参数值是否为空
+func generateParameterConversion(builder *strings.Builder, fieldName, valName, in, paramName string, field reflect.StructField, required bool) {
+	indent := "\t\t"
+	if !required {
+		builder.WriteString(fmt.Sprintf("\t\tif %s != \"\" {\n", valName))
+		indent = "\t\t\t"
+	}
+	if field.Type.Name() != "" {
+		generateTypeConversion(builder, fieldName, valName, in, paramName, field.Type, indent)
+	} else {
+		generateValueConversion(builder, fieldName, valName, in, paramName, field.Type)
+	}
+	generateValidateCall(builder, fieldName, in, paramName, field, valName, indent)
+	if !required {
 		builder.WriteString("\t\t}\n")
 	}
-	builder.WriteString("\t}\n") // 添加代码块结束
+}
+
+// generateValidateCall 生成validate标签的校验代码，校验错误收集到verrs中
+func generateValidateCall(builder *strings.Builder, fieldName, in, paramName string, field reflect.StructField, valueExpr, indent string) {
+	tag := validateTag(field)
+	if tag == "" {
+		return
+	}
+
+	// 生成阶段检查标签是否合法
+	if _, err := parseValidateRules(tag); err != nil {
+		panic(fmt.Sprintf("%v for parameter '%s'", err, paramName))
+	}
+	if err := checkEnumRule(field); err != nil {
+		panic(fmt.Sprintf("%v for parameter '%s'", err, paramName))
+	}
+
+	builder.WriteString(indent + fmt.Sprintf("if err := easygin.ValidateValue(%s, %s); err != nil {\n", fieldName, strconv.Quote(tag)))
+	builder.WriteString(indent + fmt.Sprintf("\tverrs.Add(\"%s\", \"%s\", err.Error(), %s)\n", in, paramName, fieldErrorValueExpr(in, valueExpr)))
+	builder.WriteString(indent + "}\n")
+}
+
+// codecArgs 将mime标签中声明的Codec生成为RequestCodec的参数
+func codecArgs(mime string) string {
+	var args strings.Builder
+	for _, name := range parseCodecTag(mime) {
+		args.WriteString(fmt.Sprintf(", %q", name))
+	}
+	return args.String()
+}
+
+// isFileField 判断字段是否为文件字段，包括*multipart.FileHeader和[]*multipart.FileHeader
+func isFileField(t reflect.Type) bool {
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return isFileHeaderTypeOrAlias(t)
+}
+
+// writeFieldError 生成记录字段错误并返回的代码，用于参数绑定闭包中
+func writeFieldError(builder *strings.Builder, indent, in, paramName, reason, valueExpr string) {
+	builder.WriteString(indent + fmt.Sprintf("verrs.Add(\"%s\", \"%s\", %s, %s)\n", in, paramName, strconv.Quote(reason), fieldErrorValueExpr(in, valueExpr)))
+	builder.WriteString(indent + "return\n")
+}
+
+// fieldErrorValueExpr 获取字段错误中原始值的表达式，header和cookie参数不回显原始值，与fieldErrorValue一致
+func fieldErrorValueExpr(in, valueExpr string) string {
+	if fieldErrorValue(in, valueExpr) == "" {
+		return `""`
+	}
+	return valueExpr
 }
 
 // generateValueConversion 生成值转换代码
-func generateValueConversion(builder *strings.Builder, fieldName, valName, paramName string, fieldType reflect.Type) {
+func generateValueConversion(builder *strings.Builder, fieldName, valName, in, paramName string, fieldType reflect.Type) {
 	// 处理指针类型
 	if fieldType.Kind() == reflect.Ptr {
-		builder.WriteString("\t\tif " + valName + " != \"\" {\n")                                          // 增加缩进
-		builder.WriteString(fmt.Sprintf("\t\t\ttmpVal := new(%s)\n", fieldType.Elem().Name()))             // 增加缩进
-		generateValueConversionForType(builder, "*tmpVal", valName, paramName, fieldType.Elem(), "\t\t\t") // 增加缩进
-		builder.WriteString(fmt.Sprintf("\t\t\t%s = tmpVal\n", fieldName))                                 // 增加缩进
-		builder.WriteString("\t\t}\n")                                                                     // 增加缩进
+		builder.WriteString("\t\tif " + valName + " != \"\" {\n")                                              // 增加缩进
+		builder.WriteString(fmt.Sprintf("\t\t\ttmpVal := new(%s)\n", fieldType.Elem().Name()))                 // 增加缩进
+		generateValueConversionForType(builder, "*tmpVal", valName, in, paramName, fieldType.Elem(), "\t\t\t") // 增加缩进
+		builder.WriteString(fmt.Sprintf("\t\t\t%s = tmpVal\n", fieldName))                                     // 增加缩进
+		builder.WriteString("\t\t}\n")                                                                         // 增加缩进
 		return
 	}
 
 	// 处理非指针类型
-	builder.WriteString("\t\tif " + valName + " != \"\" {\n")                                   // 增加缩进
-	generateValueConversionForType(builder, fieldName, valName, paramName, fieldType, "\t\t\t") // 增加缩进
-	builder.WriteString("\t\t}\n")                                                              // 增加缩进
+	builder.WriteString("\t\tif " + valName + " != \"\" {\n")                                       // 增加缩进
+	generateValueConversionForType(builder, fieldName, valName, in, paramName, fieldType, "\t\t\t") // 增加缩进
+	builder.WriteString("\t\t}\n")                                                                  // 增加缩进
 }
 
 // generateValueConversionForType 根据类型生成值转换代码
-func generateValueConversionForType(builder *strings.Builder, fieldName, valName, paramName string, fieldType reflect.Type, indent string) {
+func generateValueConversionForType(builder *strings.Builder, fieldName, valName, in, paramName string, fieldType reflect.Type, indent string) {
 	// 处理time.Time类型
 	if fieldType.String() == "time.Time" {
 		builder.WriteString(indent + fmt.Sprintf("t, err := time.Parse(time.RFC3339, %s)\n", valName))
 		builder.WriteString(indent + "if err != nil {\n")
-		builder.WriteString(indent + fmt.Sprintf("\treturn fmt.Errorf(\"invalid time format for parameter '%s': %%v\", err.Error())\n", paramName))
+		writeFieldError(builder, indent+"\t", in, paramName, reasonInvalidValue, valName)
 		builder.WriteString(indent + "}\n")
 		builder.WriteString(indent + "if !t.IsZero() {\n") // 检查是否为零值
 		builder.WriteString(indent + fmt.Sprintf("\t%s = t\n", fieldName))
@@ -725,7 +848,7 @@ func generateValueConversionForType(builder *strings.Builder, fieldName, valName
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		builder.WriteString(indent + fmt.Sprintf("intVal, err := strconv.ParseInt(%s, 10, 64)\n", valName))
 		builder.WriteString(indent + "if err != nil {\n")
-		builder.WriteString(indent + fmt.Sprintf("\treturn fmt.Errorf(\"invalid parameter '%s': %%v\", err.Error())\n", paramName))
+		writeFieldError(builder, indent+"\t", in, paramName, reasonInvalidValue, valName)
 		builder.WriteString(indent + "}\n")
 		builder.WriteString(indent + "if intVal != 0 {\n") // 检查是否为零值
 		builder.WriteString(indent + fmt.Sprintf("\t%s = %s(intVal)\n", fieldName, fieldType.Name()))
@@ -733,7 +856,7 @@ func generateValueConversionForType(builder *strings.Builder, fieldName, valName
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		builder.WriteString(indent + fmt.Sprintf("uintVal, err := strconv.ParseUint(%s, 10, 64)\n", valName))
 		builder.WriteString(indent + "if err != nil {\n")
-		builder.WriteString(indent + fmt.Sprintf("\treturn fmt.Errorf(\"invalid parameter '%s': %%v\", err.Error())\n", paramName))
+		writeFieldError(builder, indent+"\t", in, paramName, reasonInvalidValue, valName)
 		builder.WriteString(indent + "}\n")
 		builder.WriteString(indent + "if uintVal != 0 {\n") // 检查是否为零值
 		builder.WriteString(indent + fmt.Sprintf("\t%s = %s(uintVal)\n", fieldName, fieldType.Name()))
@@ -741,7 +864,7 @@ func generateValueConversionForType(builder *strings.Builder, fieldName, valName
 	case reflect.Float32, reflect.Float64:
 		builder.WriteString(indent + fmt.Sprintf("floatVal, err := strconv.ParseFloat(%s, 64)\n", valName))
 		builder.WriteString(indent + "if err != nil {\n")
-		builder.WriteString(indent + fmt.Sprintf("\treturn fmt.Errorf(\"invalid parameter '%s': %%v\", err.Error())\n", paramName))
+		writeFieldError(builder, indent+"\t", in, paramName, reasonInvalidValue, valName)
 		builder.WriteString(indent + "}\n")
 		builder.WriteString(indent + "if floatVal != 0 {\n") // 检查是否为零值
 		builder.WriteString(indent + fmt.Sprintf("\t%s = %s(floatVal)\n", fieldName, fieldType.Name()))
@@ -749,14 +872,14 @@ func generateValueConversionForType(builder *strings.Builder, fieldName, valName
 	case reflect.Bool:
 		builder.WriteString(indent + fmt.Sprintf("boolVal, err := strconv.ParseBool(%s)\n", valName))
 		builder.WriteString(indent + "if err != nil {\n")
-		builder.WriteString(indent + fmt.Sprintf("\treturn fmt.Errorf(\"invalid parameter '%s': %%v\", err.Error())\n", paramName))
+		writeFieldError(builder, indent+"\t", in, paramName, reasonInvalidValue, valName)
 		builder.WriteString(indent + "}\n")
 		builder.WriteString(indent + "if boolVal {\n") // 检查是否为零值
 		builder.WriteString(indent + fmt.Sprintf("\t%s = boolVal\n", fieldName))
 		builder.WriteString(indent + "}\n")
 	default:
 		builder.WriteString(indent + fmt.Sprintf("// 不支持的类型: %s\n", fieldType.Kind().String()))
-		builder.WriteString(indent + fmt.Sprintf("return errors.New(\"unsupported parameter type: %s for field %s\")\n", fieldType.Kind().String(), paramName))
+		writeFieldError(builder, indent, in, paramName, "unsupported parameter type: "+fieldType.Kind().String(), valName)
 	}
 }
 
@@ -765,7 +888,7 @@ func processRouterGroup(group *RouterGroup) ([]RouterHandler, error) {
 	var allAPIs []RouterHandler
 
 	for _, middleware := range group.middlewares {
-		if _, ok := middleware.(NoGenParameter); ok {
+		if _, ok := handlerValue(middleware).(NoGenParameter); ok {
 			continue
 		}
 		allAPIs = append(allAPIs, middleware)
@@ -831,7 +954,7 @@ func generateFileContent(pkgPath string, apis []RouterHandler) string {
 	// 对API进行去重处理，确保每个类型只处理一次
 	uniqueAPIs := make(map[string]RouterHandler)
 	for _, api := range apis {
-		apiType := reflect.TypeOf(api)
+		apiType := reflect.TypeOf(handlerValue(api))
 		if apiType.Kind() == reflect.Ptr {
 			apiType = apiType.Elem()
 		}
@@ -852,7 +975,7 @@ func generateFileContent(pkgPath string, apis []RouterHandler) string {
 	sort.Strings(typeKeys)
 	for _, key := range typeKeys {
 		api := uniqueAPIs[key]
-		apiType := reflect.TypeOf(api)
+		apiType := reflect.TypeOf(handlerValue(api))
 		if apiType.Kind() == reflect.Ptr {
 			apiType = apiType.Elem()
 		}
@@ -974,7 +1097,7 @@ func packageDirForHandler(pkgPath string, handler RouterHandler) (string, error)
 }
 
 func packageDirFromRuntime(handler RouterHandler) (string, error) {
-	t := reflect.TypeOf(handler)
+	t := reflect.TypeOf(handlerValue(handler))
 	method, ok := t.MethodByName("Output")
 	if !ok {
 		return "", fmt.Errorf("handler %T does not implement Output method", handler)
@@ -1089,7 +1212,7 @@ func parseModulePath(goModPath string) (string, error) {
 }
 
 // generateTypeConversion 生成类型转换代码
-func generateTypeConversion(builder *strings.Builder, fieldName, valName, paramName string, fieldType reflect.Type, indent string) {
+func generateTypeConversion(builder *strings.Builder, fieldName, valName, in, paramName string, fieldType reflect.Type, indent string) {
 	// 获取底层类型
 	underlyingKind := fieldType.Kind()
 
@@ -1109,30 +1232,30 @@ func generateTypeConversion(builder *strings.Builder, fieldName, valName, paramN
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		builder.WriteString(indent + fmt.Sprintf("intVal, err := strconv.ParseInt(%s, 10, 64)\n", valName))
 		builder.WriteString(indent + "if err != nil {\n")
-		builder.WriteString(indent + fmt.Sprintf("\treturn fmt.Errorf(\"invalid parameter '%s': %%v\", err.Error())\n", paramName))
+		writeFieldError(builder, indent+"\t", in, paramName, reasonInvalidValue, valName)
 		builder.WriteString(indent + "}\n")
 		builder.WriteString(indent + fmt.Sprintf("%s = %s(intVal)\n", fieldName, typeName))
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		builder.WriteString(indent + fmt.Sprintf("uintVal, err := strconv.ParseUint(%s, 10, 64)\n", valName))
 		builder.WriteString(indent + "if err != nil {\n")
-		builder.WriteString(indent + fmt.Sprintf("\treturn fmt.Errorf(\"invalid parameter '%s': %%v\", err.Error())\n", paramName))
+		writeFieldError(builder, indent+"\t", in, paramName, reasonInvalidValue, valName)
 		builder.WriteString(indent + "}\n")
 		builder.WriteString(indent + fmt.Sprintf("%s = %s(uintVal)\n", fieldName, typeName))
 	case reflect.Float32, reflect.Float64:
 		builder.WriteString(indent + fmt.Sprintf("floatVal, err := strconv.ParseFloat(%s, 64)\n", valName))
 		builder.WriteString(indent + "if err != nil {\n")
-		builder.WriteString(indent + fmt.Sprintf("\treturn fmt.Errorf(\"invalid parameter '%s': %%v\", err.Error())\n", paramName))
+		writeFieldError(builder, indent+"\t", in, paramName, reasonInvalidValue, valName)
 		builder.WriteString(indent + "}\n")
 		builder.WriteString(indent + fmt.Sprintf("%s = %s(floatVal)\n", fieldName, typeName))
 	case reflect.Bool:
 		builder.WriteString(indent + fmt.Sprintf("boolVal, err := strconv.ParseBool(%s)\n", valName))
 		builder.WriteString(indent + "if err != nil {\n")
-		builder.WriteString(indent + fmt.Sprintf("\treturn fmt.Errorf(\"invalid parameter '%s': %%v\", err.Error())\n", paramName))
+		writeFieldError(builder, indent+"\t", in, paramName, reasonInvalidValue, valName)
 		builder.WriteString(indent + "}\n")
 		builder.WriteString(indent + fmt.Sprintf("%s = %s(boolVal)\n", fieldName, typeName))
 	default:
 		// 对于其他类型，使用通用的转换方法
-		generateValueConversionForType(builder, fieldName, valName, paramName, fieldType, indent)
+		generateValueConversionForType(builder, fieldName, valName, in, paramName, fieldType, indent)
 	}
 }
 
@@ -50,12 +50,33 @@ func TestGeneratePathBindingRequired(t *testing.T) {
 	generatePathBinding(&builder, "r.ID", "item_id", field)
 	output := builder.String()
 
-	if !strings.Contains(output, `return errors.New("missing required parameter 'item_id' in path")`) {
+	if !strings.Contains(output, `verrs.Add("path", "item_id", "missing required parameter", "")`) {
 		t.Fatalf("missing required parameter guard in generated code:\n%s", output)
 	}
 	if !strings.Contains(output, `strconv.ParseInt(pathVal, 10, 64)`) {
 		t.Fatalf("missing integer conversion in generated code:\n%s", output)
 	}
+	if strings.Contains(output, `if pathVal != ""`) {
+		t.Fatalf("required parameter should not check empty value again, got:\n%s", output)
+	}
+}
+
+func TestGenerateCookieBinding(t *testing.T) {
+	type cookieStruct struct {
+		Session string `in:"cookie" name:"session"`
+	}
+	field := reflect.TypeOf(cookieStruct{}).Field(0)
+
+	var builder strings.Builder
+	generateCookieBinding(&builder, "r.Session", "session", field)
+	output := builder.String()
+
+	if !strings.Contains(output, `cookieVal, _ := c.Cookie("session")`) {
+		t.Fatalf("missing cookie lookup in generated code:\n%s", output)
+	}
+	if !strings.Contains(output, `verrs.Add("cookie", "session", "missing required parameter", "")`) {
+		t.Fatalf("missing required cookie guard in generated code:\n%s", output)
+	}
 }
 
 func TestGenerateQueryBindingOptionalDefault(t *testing.T) {
@@ -71,9 +92,12 @@ func TestGenerateQueryBindingOptionalDefault(t *testing.T) {
 	if !strings.Contains(output, `queryVal = "anonymous"`) {
 		t.Fatalf("expected default value assignment, got:\n%s", output)
 	}
-	if strings.Contains(output, `missing required parameter 'name' in query`) {
+	if strings.Contains(output, `verrs.Add("query", "name", "missing required parameter"`) {
 		t.Fatalf("omitempty field should not generate required error, got:\n%s", output)
 	}
+	if !strings.Contains(output, `if queryVal != ""`) {
+		t.Fatalf("omitempty field should skip empty value, got:\n%s", output)
+	}
 }
 
 func TestGenerateQuerySliceBindingIntPtr(t *testing.T) {
@@ -112,8 +136,8 @@ func TestGenerateBodyBindingJSONPointer(t *testing.T) {
 	if !strings.Contains(output, "if r.Payload == nil") {
 		t.Fatalf("expected pointer instantiation guard, got:\n%s", output)
 	}
-	if !strings.Contains(output, "decoder := json.NewDecoder") {
-		t.Fatalf("expected JSON decoder block, got:\n%s", output)
+	if !strings.Contains(output, "codec, err := easygin.RequestCodec(c, r.Payload)") || !strings.Contains(output, "codec.Decode(c.Request.Body, r.Payload)") {
+		t.Fatalf("expected codec decoder block, got:\n%s", output)
 	}
 	if !strings.Contains(output, "easygin.ValidateJsonRequiredFields") {
 		t.Fatalf("expected validation call, got:\n%s", output)
@@ -136,7 +160,7 @@ func TestGenerateBodyBindingMultipartForm(t *testing.T) {
 	if !strings.Contains(output, "ParseMultipartForm") {
 		t.Fatalf("expected multipart parsing block, got:\n%s", output)
 	}
-	if !strings.Contains(output, `return errors.New("missing required file 'upload'")`) {
+	if !strings.Contains(output, `verrs.Add("form", "upload", "missing required file", "")`) {
 		t.Fatalf("expected required file error, got:\n%s", output)
 	}
 	if !strings.Contains(output, `c.PostFormArray("tags")`) {
@@ -144,6 +168,86 @@ func TestGenerateBodyBindingMultipartForm(t *testing.T) {
 	}
 }
 
+func TestGenerateBodyBindingURLEncodedForm(t *testing.T) {
+	type urlencodedForm struct {
+		Form struct {
+			GrantType string `name:"grant_type"`
+		} `in:"body" mime:"urlencoded"`
+	}
+	field := reflect.TypeOf(urlencodedForm{}).Field(0)
+
+	var builder strings.Builder
+	generateBodyBinding(&builder, "r.Form", field, reflect.TypeOf(urlencodedForm{}).PkgPath())
+	output := builder.String()
+
+	if !strings.Contains(output, "c.Request.ParseForm()") || strings.Contains(output, "ParseMultipartForm") {
+		t.Fatalf("expected urlencoded parsing block, got:\n%s", output)
+	}
+	if !strings.Contains(output, `c.PostForm("grant_type")`) {
+		t.Fatalf("expected form field binding, got:\n%s", output)
+	}
+
+	type urlencodedFile struct {
+		Form struct {
+			Upload *multipart.FileHeader `name:"upload"`
+		} `in:"body" mime:"urlencoded"`
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected file field in urlencoded body to panic")
+		}
+	}()
+	generateBodyBinding(&builder, "r.Form", reflect.TypeOf(urlencodedFile{}).Field(0), "")
+}
+
+func TestGenerateBindParametersMethodValidate(t *testing.T) {
+	output := generateBindParametersMethod(reflect.TypeOf(validateBindingAPI{}), reflect.TypeOf(validateBindingAPI{}).PkgPath())
+
+	if !strings.Contains(output, "var verrs easygin.ValidationError") {
+		t.Fatalf("expected validation error collector, got:\n%s", output)
+	}
+	if !strings.Contains(output, `easygin.ValidateValue(r.Size, "min=1,max=100")`) {
+		t.Fatalf("expected query validation call, got:\n%s", output)
+	}
+	if !strings.Contains(output, `verrs.Add("query", "size", err.Error(), queryVal)`) {
+		t.Fatalf("expected query validation error collection, got:\n%s", output)
+	}
+	if !strings.Contains(output, "easygin.ValidateJsonFields(reflect.ValueOf(&r.Body), &verrs)") {
+		t.Fatalf("expected body validation call, got:\n%s", output)
+	}
+	if !strings.Contains(output, "return verrs.Err()") {
+		t.Fatalf("expected collected validation errors to be returned, got:\n%s", output)
+	}
+
+	plain := generateBindParametersMethod(reflect.TypeOf(headerOnlyAPI{}), reflect.TypeOf(headerOnlyAPI{}).PkgPath())
+	if strings.Contains(plain, "easygin.ValidateValue") {
+		t.Fatalf("expected no validation code without validate tags, got:\n%s", plain)
+	}
+	if !strings.Contains(plain, `verrs.Add("header", "x-flag", "invalid value", "")`) {
+		t.Fatalf("expected conversion errors to be collected without header value, got:\n%s", plain)
+	}
+}
+
+func TestGenerateBodyBindingCodecRestriction(t *testing.T) {
+	type bodyStruct struct {
+		Payload struct {
+			Field string `json:"field" xml:"field"`
+		} `in:"body" mime:"json,xml"`
+	}
+	field := reflect.TypeOf(bodyStruct{}).Field(0)
+
+	var builder strings.Builder
+	generateBodyBinding(&builder, "r.Payload", field, reflect.TypeOf(bodyStruct{}).PkgPath())
+	output := builder.String()
+
+	if !strings.Contains(output, `easygin.RequestCodec(c, &r.Payload, "json", "xml")`) {
+		t.Fatalf("expected codec restriction from mime tag, got:\n%s", output)
+	}
+	if !strings.Contains(output, "codec.Decode(c.Request.Body, &r.Payload)") {
+		t.Fatalf("expected codec decode into addressable body, got:\n%s", output)
+	}
+}
+
 func TestGenerateFileContentDedupAndImports(t *testing.T) {
 	content := generateFileContent(
 		"github.com/zboyco/easygin/custompkg",
@@ -159,9 +263,6 @@ func TestGenerateFileContentDedupAndImports(t *testing.T) {
 	}
 
 	for _, pkg := range []string{
-		"\"encoding/json\"",
-		"\"errors\"",
-		"\"fmt\"",
 		"\"reflect\"",
 		"\"strconv\"",
 		"\"strings\"",
@@ -174,6 +275,13 @@ func TestGenerateFileContentDedupAndImports(t *testing.T) {
 		}
 	}
 
+	// 绑定错误统一收集到verrs中，不再引用errors和fmt，请求体通过Codec解码，不再引用encoding/json
+	for _, pkg := range []string{"\"errors\"", "\"fmt\"", "\"encoding/json\""} {
+		if strings.Contains(content, pkg) {
+			t.Fatalf("unexpected import %s in generated content:\n%s", pkg, content)
+		}
+	}
+
 	if !strings.Contains(content, "package custompkg") {
 		t.Fatalf("expected package declaration for custompkg, got:\n%s", content)
 	}
@@ -261,3 +369,14 @@ func (a *headerOnlyAPI) Method() string { return "GET" }
 func (a *headerOnlyAPI) Path() string { return "/header" }
 
 func (a *headerOnlyAPI) Output(context.Context) (any, error) { return nil, nil }
+
+type validateBindingAPI struct {
+	Size int `in:"query" name:"size,omitempty" default:"10" validate:"min=1,max=100"`
+	Body struct {
+		Email string `json:"email" validate:"email"`
+	} `in:"body"`
+}
+
+func (validateBindingAPI) Output(context.Context) (any, error) {
+	return nil, nil
+}
@@ -0,0 +1,590 @@
+package easygin
+
+import (
+	"fmt"
+	"go/format"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// clientFileName 生成的客户端文件名
+const clientFileName = "zz_easygin_client.go"
+
+// clientAPI 需要生成客户端方法的API
+type clientAPI struct {
+	api        RouterAPI
+	apiType    reflect.Type // API结构体类型
+	path       string       // 完整的路由路径，保留:param格式
+	methodName string       // 客户端方法名
+}
+
+// clientImports 记录生成的客户端文件引用的包及其别名
+type clientImports struct {
+	aliases map[string]string // 包路径到别名
+	paths   map[string]string // 别名到包路径
+	used    map[string]bool   // 已使用的包路径
+}
+
+func newClientImports() *clientImports {
+	ci := &clientImports{
+		aliases: make(map[string]string),
+		paths:   make(map[string]string),
+		used:    make(map[string]bool),
+	}
+	// 生成代码中固定使用的包
+	for _, pkgPath := range []string{"context", "mime/multipart", "net/http", "net/url", "github.com/zboyco/easygin"} {
+		ci.alias(pkgPath)
+	}
+	return ci
+}
+
+// alias 获取包的别名，同名的包按照出现顺序添加数字后缀
+func (ci *clientImports) alias(pkgPath string) string {
+	if alias, ok := ci.aliases[pkgPath]; ok {
+		return alias
+	}
+	base := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, filepath.Base(pkgPath))
+	alias := base
+	for i := 2; ci.paths[alias] != ""; i++ {
+		alias = base + strconv.Itoa(i)
+	}
+	ci.aliases[pkgPath] = alias
+	ci.paths[alias] = pkgPath
+	return alias
+}
+
+// use 标记包已使用并返回别名
+func (ci *clientImports) use(pkgPath string) string {
+	ci.used[pkgPath] = true
+	return ci.alias(pkgPath)
+}
+
+// typeName 获取类型在生成代码中的写法，匿名结构体、泛型和未导出类型无法引用，返回false
+func (ci *clientImports) typeName(t reflect.Type) (string, bool) {
+	if t.Name() != "" {
+		if t.PkgPath() == "" {
+			return t.Name(), true
+		}
+		if strings.Contains(t.Name(), "[") || !isExportedName(t.Name()) {
+			return "", false
+		}
+		return ci.use(t.PkgPath()) + "." + t.Name(), true
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		elem, ok := ci.typeName(t.Elem())
+		return "*" + elem, ok
+	case reflect.Slice:
+		elem, ok := ci.typeName(t.Elem())
+		return "[]" + elem, ok
+	case reflect.Array:
+		elem, ok := ci.typeName(t.Elem())
+		return fmt.Sprintf("[%d]%s", t.Len(), elem), ok
+	case reflect.Map:
+		key, keyOK := ci.typeName(t.Key())
+		elem, elemOK := ci.typeName(t.Elem())
+		return fmt.Sprintf("map[%s]%s", key, elem), keyOK && elemOK
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			return "any", true
+		}
+	}
+	return "", false
+}
+
+// GenerateClient 根据路由组生成Go客户端，写入dir目录下的zz_easygin_client.go
+// 每个API生成一个方法，参数为API结构体，返回Responses()中声明的2xx响应体
+func GenerateClient(dir string, groups ...*RouterGroup) error {
+	if len(groups) == 0 {
+		return fmt.Errorf("no router groups provided")
+	}
+
+	apis := make([]*clientAPI, 0)
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+		apis = append(apis, collectClientAPIs(group, "")...)
+	}
+	if len(apis) == 0 {
+		return fmt.Errorf("no APIs found in any router group")
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	content, err := generateClientContent(filepath.Base(absDir), apis)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(absDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %s, error: %v", absDir, err)
+	}
+	filePath := filepath.Join(absDir, clientFileName)
+	if err := os.WriteFile(filePath, content, 0o644); err != nil {
+		return fmt.Errorf("failed to write file: %s, error: %v", filePath, err)
+	}
+
+	fmt.Printf("Successfully generated client: %s\n", filePath)
+	return nil
+}
+
+// collectClientAPIs 收集路由组中需要生成客户端方法的API
+// 跳过不生成文档的内部API、GinHandler、WebSocket和ANY方法的API
+func collectClientAPIs(group *RouterGroup, parentPath string) []*clientAPI {
+	basePath := joinURLPath(parentPath, group.path)
+
+	apis := make([]*clientAPI, 0)
+	for _, api := range group.apis {
+		apiType := reflect.TypeOf(api)
+		for apiType.Kind() == reflect.Ptr {
+			apiType = apiType.Elem()
+		}
+
+		skip := ""
+		switch {
+		case isNoOpenAPI(api):
+			continue
+		case isGinHandler(api):
+			skip = "GinHandler"
+		case isWebSocketHandler(api):
+			skip = "WebSocket"
+		case api.Method() == "ANY":
+			skip = "MethodAny"
+		case !isExportedName(apiType.Name()):
+			skip = "unexported type"
+		case hasEventStreamResponse(api):
+			skip = "EventStream"
+		}
+		if skip != "" {
+			fmt.Printf("Warning: skip client method for %s (%s)\n", apiType.String(), skip)
+			continue
+		}
+
+		apis = append(apis, &clientAPI{
+			api:     api,
+			apiType: apiType,
+			path:    "/" + strings.TrimPrefix(joinURLPath(basePath, api.Path()), "/"),
+		})
+	}
+
+	for _, child := range group.children {
+		apis = append(apis, collectClientAPIs(child, basePath)...)
+	}
+	return apis
+}
+
+// generateClientContent 生成客户端文件内容
+func generateClientContent(pkgName string, apis []*clientAPI) ([]byte, error) {
+	imports := newClientImports()
+	assignClientMethodNames(apis, imports)
+
+	var methods strings.Builder
+	for _, api := range apis {
+		if err := generateClientMethod(&methods, api, imports); err != nil {
+			return nil, err
+		}
+	}
+
+	var builder strings.Builder
+	builder.WriteString("// Code generated by easygin; DO NOT EDIT.\n\n")
+	builder.WriteString(fmt.Sprintf("package %s\n\n", pkgName))
+
+	// 生成代码固定使用的包
+	imports.use("context")
+	imports.use("net/http")
+	imports.use("github.com/zboyco/easygin")
+
+	pkgPaths := make([]string, 0, len(imports.used))
+	for pkgPath := range imports.used {
+		pkgPaths = append(pkgPaths, pkgPath)
+	}
+	sort.Strings(pkgPaths)
+
+	// 标准库和第三方包分组
+	builder.WriteString("import (\n")
+	for _, std := range []bool{true, false} {
+		if !std {
+			builder.WriteString("\n")
+		}
+		for _, pkgPath := range pkgPaths {
+			if isStdPackage(pkgPath) != std {
+				continue
+			}
+			alias := imports.alias(pkgPath)
+			if alias == filepath.Base(pkgPath) {
+				builder.WriteString(fmt.Sprintf("\t%q\n", pkgPath))
+			} else {
+				builder.WriteString(fmt.Sprintf("\t%s %q\n", alias, pkgPath))
+			}
+		}
+	}
+	builder.WriteString(")\n\n")
+
+	builder.WriteString("// Client 由easygin根据路由组生成的HTTP客户端\n")
+	builder.WriteString("type Client struct {\n")
+	builder.WriteString("\t*easygin.Client\n")
+	builder.WriteString("}\n\n")
+	builder.WriteString("// NewClient 创建客户端，baseURL为服务地址，如http://127.0.0.1:8080\n")
+	builder.WriteString("func NewClient(baseURL string) *Client {\n")
+	builder.WriteString("\treturn &Client{Client: easygin.NewClient(baseURL)}\n")
+	builder.WriteString("}\n")
+	builder.WriteString(methods.String())
+
+	content, err := format.Source([]byte(builder.String()))
+	if err != nil {
+		return nil, fmt.Errorf("format generated client failed: %w", err)
+	}
+	return content, nil
+}
+
+// assignClientMethodNames 为API分配客户端方法名，默认使用类型名，类型名重复时添加包名前缀
+func assignClientMethodNames(apis []*clientAPI, imports *clientImports) {
+	count := make(map[string]int)
+	for _, api := range apis {
+		count[api.apiType.Name()]++
+	}
+	used := make(map[string]bool)
+	for _, api := range apis {
+		name := api.apiType.Name()
+		if count[name] > 1 {
+			alias := imports.alias(api.apiType.PkgPath())
+			name = strings.ToUpper(alias[:1]) + alias[1:] + name
+		}
+		// 同一个API注册到多个路由时添加数字后缀
+		base := name
+		for i := 2; used[name]; i++ {
+			name = base + strconv.Itoa(i)
+		}
+		used[name] = true
+		api.methodName = name
+	}
+}
+
+// generateClientMethod 生成单个API的客户端方法
+func generateClientMethod(builder *strings.Builder, api *clientAPI, imports *clientImports) error {
+	reqType := imports.use(api.apiType.PkgPath()) + "." + api.apiType.Name()
+
+	// 获取2xx响应体，多个2xx响应使用状态码最小且类型相同的响应体
+	var (
+		respType  reflect.Type
+		respCodes []int
+	)
+	if responder, ok := api.api.(RouterResponse); ok {
+		codes := make([]int, 0)
+		for code, resp := range responder.Responses() {
+			if code >= http.StatusOK && code < http.StatusMultipleChoices && resp != nil {
+				codes = append(codes, code)
+			}
+		}
+		sort.Ints(codes)
+		responses := responder.Responses()
+		for _, code := range codes {
+			t := reflect.TypeOf(responses[code])
+			if respType == nil {
+				respType = t
+			}
+			if t == respType {
+				respCodes = append(respCodes, code)
+			}
+		}
+	}
+
+	var (
+		returnType string
+		errReturn  = "return err"
+	)
+	if respType != nil {
+		name, ok := imports.typeName(respType)
+		if !ok {
+			// 无法引用的响应体类型使用any解码
+			name = "any"
+			respType = reflect.TypeOf((*any)(nil)).Elem()
+		}
+		returnType = name
+		if respType.Kind() == reflect.Ptr || respType.Kind() == reflect.Slice || respType.Kind() == reflect.Map || respType.Kind() == reflect.Interface {
+			errReturn = "return nil, err"
+		} else {
+			errReturn = fmt.Sprintf("return *new(%s), err", name)
+		}
+	}
+
+	// 方法注释
+	builder.WriteString("\n")
+	if summary := getHandlerDescription(api.api); summary != "" {
+		builder.WriteString(fmt.Sprintf("// %s %s\n", api.methodName, summary))
+		builder.WriteString(fmt.Sprintf("// %s %s\n", api.api.Method(), api.path))
+	} else {
+		builder.WriteString(fmt.Sprintf("// %s %s %s\n", api.methodName, api.api.Method(), api.path))
+	}
+	if isHandlerDeprecated(api.api) {
+		builder.WriteString("//\n// Deprecated: 该API已废弃\n")
+	}
+	if returnType != "" {
+		builder.WriteString(fmt.Sprintf("func (c *Client) %s(ctx context.Context, req *%s) (%s, error) {\n", api.methodName, reqType, returnType))
+	} else {
+		builder.WriteString(fmt.Sprintf("func (c *Client) %s(ctx context.Context, req *%s) error {\n", api.methodName, reqType))
+	}
+	builder.WriteString(fmt.Sprintf("\tr := easygin.NewClientRequest(%s, %q)\n", httpMethodExpr(api.api.Method()), api.path))
+
+	if err := generateClientParams(builder, api.apiType, "req", errReturn, imports); err != nil {
+		return fmt.Errorf("%s: %w", api.apiType.String(), err)
+	}
+
+	if returnType == "" {
+		builder.WriteString("\treturn c.Do(ctx, r)\n")
+		builder.WriteString("}\n")
+		return nil
+	}
+
+	codes := make([]string, 0, len(respCodes))
+	if respType.Kind() == reflect.Ptr {
+		builder.WriteString(fmt.Sprintf("\tresp := new(%s)\n", strings.TrimPrefix(returnType, "*")))
+		for _, code := range respCodes {
+			codes = append(codes, fmt.Sprintf("%d: resp", code))
+		}
+	} else {
+		builder.WriteString(fmt.Sprintf("\tvar resp %s\n", returnType))
+		for _, code := range respCodes {
+			codes = append(codes, fmt.Sprintf("%d: &resp", code))
+		}
+	}
+	builder.WriteString(fmt.Sprintf("\tr.Responses = map[int]any{%s}\n", strings.Join(codes, ", ")))
+	builder.WriteString("\tif err := c.Do(ctx, r); err != nil {\n")
+	builder.WriteString("\t\t" + errReturn + "\n")
+	builder.WriteString("\t}\n")
+	builder.WriteString("\treturn resp, nil\n")
+	builder.WriteString("}\n")
+	return nil
+}
+
+// generateClientParams 生成序列化API参数的代码，嵌入字段的参数通过字段提升访问
+func generateClientParams(builder *strings.Builder, t reflect.Type, prefix, errReturn string, imports *clientImports) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		inTag := field.Tag.Get("in")
+
+		if field.Anonymous && inTag == "" {
+			embedType := field.Type
+			if embedType.Kind() == reflect.Ptr {
+				embedType = embedType.Elem()
+			}
+			if embedType.Kind() == reflect.Struct {
+				if err := generateClientParams(builder, embedType, prefix, errReturn, imports); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if inTag == "" {
+			continue
+		}
+
+		nameParts := strings.Split(field.Tag.Get("name"), ",")
+		name := nameParts[0]
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		if name == "-" {
+			continue
+		}
+		omitempty := len(nameParts) > 1 && nameParts[1] == "omitempty"
+		expr := prefix + "." + field.Name
+
+		switch inTag {
+		case "path":
+			builder.WriteString(fmt.Sprintf("\tr.SetPathParam(%q, %s)\n", name, expr))
+		case "query":
+			generateClientValue(builder, expr, field.Type, omitempty, "\t", func(v string) string {
+				return fmt.Sprintf("r.Query.Add(%q, easygin.FormatParameter(%s))", name, v)
+			})
+		case "header":
+			generateClientValue(builder, expr, field.Type, omitempty, "\t", func(v string) string {
+				return fmt.Sprintf("r.Header.Add(%q, easygin.FormatParameter(%s))", name, v)
+			})
+		case "cookie":
+			generateClientValue(builder, expr, field.Type, omitempty, "\t", func(v string) string {
+				return fmt.Sprintf("r.AddCookie(%q, %s)", name, v)
+			})
+		case "body":
+			if err := generateClientBody(builder, expr, field, errReturn, imports); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// generateClientValue 生成单个参数的序列化代码
+// 切片逐个添加，指针为nil时跳过，带omitempty的参数为零值时跳过
+func generateClientValue(builder *strings.Builder, expr string, t reflect.Type, omitempty bool, indent string, add func(v string) string) {
+	switch {
+	case t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8:
+		builder.WriteString(fmt.Sprintf("%sfor _, v := range %s {\n", indent, expr))
+		builder.WriteString(fmt.Sprintf("%s\t%s\n", indent, add("v")))
+		builder.WriteString(indent + "}\n")
+	case t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Slice:
+		builder.WriteString(fmt.Sprintf("%sif %s != nil {\n", indent, expr))
+		builder.WriteString(fmt.Sprintf("%s\tfor _, v := range *%s {\n", indent, expr))
+		builder.WriteString(fmt.Sprintf("%s\t\t%s\n", indent, add("v")))
+		builder.WriteString(indent + "\t}\n")
+		builder.WriteString(indent + "}\n")
+	case t.Kind() == reflect.Ptr:
+		builder.WriteString(fmt.Sprintf("%sif %s != nil {\n", indent, expr))
+		builder.WriteString(fmt.Sprintf("%s\t%s\n", indent, add(expr)))
+		builder.WriteString(indent + "}\n")
+	case omitempty:
+		builder.WriteString(fmt.Sprintf("%sif !easygin.IsZeroParameter(%s) {\n", indent, expr))
+		builder.WriteString(fmt.Sprintf("%s\t%s\n", indent, add(expr)))
+		builder.WriteString(indent + "}\n")
+	default:
+		builder.WriteString(fmt.Sprintf("%s%s\n", indent, add(expr)))
+	}
+}
+
+// generateClientBody 生成请求体的序列化代码
+// multipart和urlencoded表单按照name标签逐个字段序列化，其他请求体编码为JSON
+func generateClientBody(builder *strings.Builder, expr string, field reflect.StructField, errReturn string, imports *clientImports) error {
+	mime := field.Tag.Get("mime")
+	if mime != "multipart" && mime != "urlencoded" {
+		builder.WriteString(fmt.Sprintf("\tif err := r.SetJSONBody(%s); err != nil {\n", expr))
+		builder.WriteString("\t\t" + errReturn + "\n")
+		builder.WriteString("\t}\n")
+		return nil
+	}
+
+	bodyType := field.Type
+	indent := "\t"
+	builder.WriteString(fmt.Sprintf("\tform := %s.Values{}\n", imports.use("net/url")))
+	if mime == "multipart" {
+		builder.WriteString(fmt.Sprintf("\tfiles := map[string][]*%s.FileHeader{}\n", imports.use("mime/multipart")))
+	}
+	if bodyType.Kind() == reflect.Ptr {
+		bodyType = bodyType.Elem()
+		builder.WriteString(fmt.Sprintf("\tif %s != nil {\n", expr))
+		indent = "\t\t"
+	}
+
+	for i := 0; i < bodyType.NumField(); i++ {
+		subField := bodyType.Field(i)
+		nameParts := strings.Split(subField.Tag.Get("name"), ",")
+		name := nameParts[0]
+		if name == "" {
+			name = strings.ToLower(subField.Name)
+		}
+		omitempty := len(nameParts) > 1 && nameParts[1] == "omitempty"
+		subExpr := expr + "." + subField.Name
+
+		if isFileField(subField.Type) {
+			if mime == "urlencoded" {
+				return fmt.Errorf("file field '%s' is not supported in `mime:\"urlencoded\"` body", name)
+			}
+			if subField.Type.Kind() == reflect.Slice {
+				builder.WriteString(fmt.Sprintf("%sfiles[%q] = append(files[%q], %s...)\n", indent, name, name, subExpr))
+			} else {
+				builder.WriteString(fmt.Sprintf("%sif %s != nil {\n", indent, subExpr))
+				builder.WriteString(fmt.Sprintf("%s\tfiles[%q] = append(files[%q], %s)\n", indent, name, name, subExpr))
+				builder.WriteString(indent + "}\n")
+			}
+			continue
+		}
+		generateClientValue(builder, subExpr, subField.Type, omitempty, indent, func(v string) string {
+			return fmt.Sprintf("form.Add(%q, easygin.FormatParameter(%s))", name, v)
+		})
+	}
+
+	if bodyType != field.Type {
+		builder.WriteString("\t}\n")
+	}
+	if mime == "multipart" {
+		builder.WriteString("\tif err := r.SetMultipartBody(form, files); err != nil {\n")
+		builder.WriteString("\t\t" + errReturn + "\n")
+		builder.WriteString("\t}\n")
+	} else {
+		builder.WriteString("\tr.SetURLEncodedBody(form)\n")
+	}
+	return nil
+}
+
+// httpMethodExpr 获取HTTP方法在生成代码中的写法
+func httpMethodExpr(method string) string {
+	switch method {
+	case http.MethodGet:
+		return "http.MethodGet"
+	case http.MethodPost:
+		return "http.MethodPost"
+	case http.MethodPut:
+		return "http.MethodPut"
+	case http.MethodDelete:
+		return "http.MethodDelete"
+	case http.MethodPatch:
+		return "http.MethodPatch"
+	case http.MethodHead:
+		return "http.MethodHead"
+	case http.MethodOptions:
+		return "http.MethodOptions"
+	case http.MethodConnect:
+		return "http.MethodConnect"
+	case http.MethodTrace:
+		return "http.MethodTrace"
+	default:
+		return strconv.Quote(method)
+	}
+}
+
+// hasEventStreamResponse 判断API是否声明了事件流响应
+func hasEventStreamResponse(api RouterAPI) bool {
+	responder, ok := api.(RouterResponse)
+	if !ok {
+		return false
+	}
+	for _, resp := range responder.Responses() {
+		switch resp.(type) {
+		case EventStream, *EventStream:
+			return true
+		}
+	}
+	return false
+}
+
+func isNoOpenAPI(api RouterAPI) bool {
+	_, ok := api.(NoOpenAPI)
+	return ok
+}
+
+func isGinHandler(api RouterAPI) bool {
+	_, ok := api.(GinHandler)
+	return ok
+}
+
+func isWebSocketHandler(api RouterAPI) bool {
+	_, ok := api.(WebSocketHandler)
+	return ok
+}
+
+// isStdPackage 判断包是否为标准库，标准库包路径的第一段不包含点号
+func isStdPackage(pkgPath string) bool {
+	return !strings.Contains(strings.SplitN(pkgPath, "/", 2)[0], ".")
+}
+
+// isExportedName 判断名称是否为导出的标识符
+func isExportedName(name string) bool {
+	for _, r := range name {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
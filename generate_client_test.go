@@ -0,0 +1,72 @@
+package easygin
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestGenerateClientContent(t *testing.T) {
+	root := NewRouterGroup("/api")
+	root.RegisterAPI(&TestClientAPI{})
+	root.RegisterAPI(&testWebSocketAPI{})
+
+	apis := collectClientAPIs(root, "")
+	if len(apis) != 1 || apis[0].path != "/api/items/:id" {
+		t.Fatalf("expected only exported http api to be collected, got %+v", apis)
+	}
+
+	content, err := generateClientContent("client", apis)
+	if err != nil {
+		t.Fatalf("generateClientContent returned error: %v", err)
+	}
+	code := string(content)
+	for _, expected := range []string{
+		"package client",
+		`"github.com/zboyco/easygin"`,
+		"func (c *Client) TestClientAPI(ctx context.Context, req *easygin.TestClientAPI) (*easygin.TestClientResp, error) {",
+		`r := easygin.NewClientRequest(http.MethodPost, "/api/items/:id")`,
+		`r.SetPathParam("id", req.ID)`,
+		`if !easygin.IsZeroParameter(req.Tag) {`,
+		`r.Header.Add("Token", easygin.FormatParameter(req.Token))`,
+		"if err := r.SetJSONBody(req.Body); err != nil {",
+		"r.Responses = map[int]any{200: resp}",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Fatalf("expected generated client to contain %q, got:\n%s", expected, code)
+		}
+	}
+}
+
+func TestGenerateClientURLEncodedBody(t *testing.T) {
+	root := NewRouterGroup("/")
+	root.RegisterAPI(&TestClientTokenAPI{})
+
+	content, err := generateClientContent("client", collectClientAPIs(root, ""))
+	if err != nil {
+		t.Fatalf("generateClientContent returned error: %v", err)
+	}
+	code := string(content)
+	for _, expected := range []string{
+		`form.Add("grant_type", easygin.FormatParameter(req.Body.GrantType))`,
+		`form.Add("scope", easygin.FormatParameter(v))`,
+		"r.SetURLEncodedBody(form)",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Fatalf("expected generated client to contain %q, got:\n%s", expected, code)
+		}
+	}
+}
+
+type TestClientTokenAPI struct {
+	MethodPost
+	Body TestURLEncodedBody `in:"body" mime:"urlencoded"`
+}
+
+func (TestClientTokenAPI) Path() string {
+	return "/token"
+}
+
+func (api *TestClientTokenAPI) Output(ctx context.Context) (any, error) {
+	return nil, nil
+}
@@ -0,0 +1,649 @@
+package easygin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+var (
+	// tsIdentifier 匹配可以直接作为TypeScript属性名的标识符
+	tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+	// tsInvalidChars 匹配不能出现在TypeScript标识符中的字符
+	tsInvalidChars = regexp.MustCompile(`[^A-Za-z0-9_$]`)
+)
+
+// tsReservedNames 生成的TypeScript文件中运行时代码使用的名称，以及会被类型定义遮蔽的全局对象
+var tsReservedNames = []string{
+	"config", "EasyGinError", "RequestParams", "request", "decodeError", "each", "pathParam",
+	"Array", "Blob", "Boolean", "Date", "Error", "FormData", "Headers", "Map", "Number", "Object",
+	"Promise", "Record", "Request", "RequestInit", "Response", "Set", "String", "URLSearchParams",
+}
+
+// tsGenerator 根据OpenAPI文档生成TypeScript代码
+// 类型定义复用generateSchemaValue生成的schema，json标签的omitempty对应可选属性，",string"对应string类型
+type tsGenerator struct {
+	doc   *openapi3.T
+	names map[string]string // 组件名到TypeScript类型名
+	used  map[string]bool   // 已使用的TypeScript名称
+}
+
+// GenerateTypeScript 根据路由组生成TypeScript类型定义和基于fetch的请求函数，写入file
+// 错误响应使用默认的JSONErrorRenderer格式
+func GenerateTypeScript(file string, groups ...*RouterGroup) error {
+	return generateTypeScript(JSONErrorRenderer{}, OpenAPIOptions{}, file, groups...)
+}
+
+// generateTypeScript 生成TypeScript客户端，错误响应的格式由renderer决定
+func generateTypeScript(renderer ErrorRenderer, opts OpenAPIOptions, file string, groups ...*RouterGroup) error {
+	// 类型转换基于3.0的schema写法，与文档输出的版本无关
+	opts.OpenAPIVersion = OpenAPIVersion30
+	doc, err := buildOpenAPIDoc(renderer, opts, groups...)
+	if err != nil {
+		return err
+	}
+
+	// 收集API引用的具名类型，用于生成简短的TypeScript类型名
+	types := make(map[string]reflect.Type)
+	for _, err := range []error{&Error{}, &ValidationError{}} {
+		collectSchemaTypes(reflect.TypeOf(renderer.Model(err)), types)
+	}
+	for _, group := range groups {
+		collectGroupSchemaTypes(group, types)
+	}
+
+	content := newTSGenerator(doc, types).generate()
+
+	if dir := filepath.Dir(file); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create directory: %s, error: %v", dir, err)
+		}
+	}
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("failed to write file: %s, error: %v", file, err)
+	}
+
+	fmt.Printf("Successfully generated TypeScript client: %s\n", file)
+	return nil
+}
+
+// collectGroupSchemaTypes 收集路由组中API及其响应引用的类型
+func collectGroupSchemaTypes(group *RouterGroup, types map[string]reflect.Type) {
+	if group == nil {
+		return
+	}
+	for _, api := range group.apis {
+		collectSchemaTypes(reflect.TypeOf(api), types)
+		if responder, ok := api.(RouterResponse); ok {
+			for _, resp := range responder.Responses() {
+				if stream, ok := resp.(*EventStream); ok && stream != nil {
+					resp = stream.Model
+				} else if stream, ok := resp.(EventStream); ok {
+					resp = stream.Model
+				}
+				if resp != nil {
+					collectSchemaTypes(reflect.TypeOf(resp), types)
+				}
+			}
+		}
+	}
+	for _, child := range group.children {
+		collectGroupSchemaTypes(child, types)
+	}
+}
+
+// collectSchemaTypes 收集类型t引用的结构体类型，键为generateSchema使用的组件名
+func collectSchemaTypes(t reflect.Type, types map[string]reflect.Type) {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Map {
+		collectSchemaTypes(t.Elem(), types)
+		return
+	}
+	if t.Kind() != reflect.Struct || isTimeTypeOrAlias(t) || isFileHeaderTypeOrAlias(t) {
+		return
+	}
+	if t.PkgPath() != "" && t.Name() != "" {
+		schemaName := snakeToPascalCase(t.PkgPath() + "." + t.Name())
+		if _, ok := types[schemaName]; ok {
+			return
+		}
+		types[schemaName] = t
+	}
+	for i := 0; i < t.NumField(); i++ {
+		collectSchemaTypes(t.Field(i).Type, types)
+	}
+}
+
+func newTSGenerator(doc *openapi3.T, types map[string]reflect.Type) *tsGenerator {
+	g := &tsGenerator{
+		doc:   doc,
+		names: make(map[string]string),
+		used:  make(map[string]bool),
+	}
+	for _, name := range tsReservedNames {
+		g.used[name] = true
+	}
+
+	schemaNames := make([]string, 0, len(doc.Components.Schemas))
+	for name := range doc.Components.Schemas {
+		schemaNames = append(schemaNames, name)
+	}
+	sort.Strings(schemaNames)
+
+	// 默认使用Go类型名，重名或者与保留名称冲突时添加包名前缀，仍然冲突时使用完整的组件名
+	count := make(map[string]int)
+	for _, name := range schemaNames {
+		if t, ok := types[name]; ok {
+			count[tsTypeName(t.Name())]++
+		}
+	}
+	for _, name := range schemaNames {
+		tsName := name
+		if t, ok := types[name]; ok {
+			tsName = tsTypeName(t.Name())
+			if count[tsName] > 1 || g.used[tsName] {
+				tsName = snakeToPascalCase(filepath.Base(t.PkgPath())) + tsName
+			}
+		}
+		g.names[name] = g.unique(tsName, name)
+	}
+	return g
+}
+
+// tsTypeName 去掉泛型类型名中不能作为标识符的字符
+func tsTypeName(name string) string {
+	return tsInvalidChars.ReplaceAllString(name, "")
+}
+
+// unique 返回未使用的名称，名称已使用时依次尝试fallback和数字后缀
+func (g *tsGenerator) unique(name, fallback string) string {
+	if g.used[name] && fallback != "" {
+		name = fallback
+	}
+	base := name
+	for i := 2; g.used[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	g.used[name] = true
+	return name
+}
+
+// generate 生成完整的TypeScript文件
+func (g *tsGenerator) generate() string {
+	var builder strings.Builder
+	builder.WriteString("// Code generated by easygin; DO NOT EDIT.\n")
+	builder.WriteString("/* eslint-disable */\n\n")
+	builder.WriteString(tsRuntime)
+
+	// 类型定义
+	schemaNames := make([]string, 0, len(g.names))
+	for name := range g.names {
+		schemaNames = append(schemaNames, name)
+	}
+	sort.Slice(schemaNames, func(i, j int) bool {
+		return g.names[schemaNames[i]] < g.names[schemaNames[j]]
+	})
+	for _, name := range schemaNames {
+		g.writeSchema(&builder, g.names[name], g.doc.Components.Schemas[name].Value)
+	}
+
+	// 请求函数
+	paths := make([]string, 0, g.doc.Paths.Len())
+	for path := range g.doc.Paths.Map() {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		operations := g.doc.Paths.Value(path).Operations()
+		methods := make([]string, 0, len(operations))
+		for method := range operations {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		for _, method := range methods {
+			g.writeOperation(&builder, method, path, operations[method])
+		}
+	}
+	return builder.String()
+}
+
+// writeSchema 生成组件schema对应的interface
+func (g *tsGenerator) writeSchema(builder *strings.Builder, name string, schema *openapi3.Schema) {
+	builder.WriteString("\n")
+	writeTSDoc(builder, "", schema.Description)
+	if schema.Properties == nil {
+		builder.WriteString(fmt.Sprintf("export type %s = %s;\n", name, g.schemaType(schema)))
+		return
+	}
+	builder.WriteString(fmt.Sprintf("export interface %s {\n", name))
+	g.writeProperties(builder, "  ", schema)
+	builder.WriteString("}\n")
+}
+
+// writeProperties 按照属性名排序生成对象属性，不在required中的属性为可选属性
+func (g *tsGenerator) writeProperties(builder *strings.Builder, indent string, schema *openapi3.Schema) {
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		prop := schema.Properties[name]
+		if prop.Value != nil {
+			writeTSDoc(builder, indent, prop.Value.Description)
+		}
+		builder.WriteString(fmt.Sprintf("%s%s%s: %s;\n", indent, tsPropertyName(name), optionalMark(schema.Required, name), g.typeOf(prop)))
+	}
+}
+
+// typeOf 获取schema引用对应的TypeScript类型
+func (g *tsGenerator) typeOf(ref *openapi3.SchemaRef) string {
+	if ref == nil {
+		return "unknown"
+	}
+	if ref.Ref != "" {
+		return g.refName(ref.Ref)
+	}
+	return g.schemaType(ref.Value)
+}
+
+// schemaType 将schema转换为TypeScript类型，generateSchema生成的引用保存在$ref扩展字段中
+func (g *tsGenerator) schemaType(schema *openapi3.Schema) string {
+	if schema == nil {
+		return "unknown"
+	}
+	if schema.Nullable {
+		nonNull := *schema
+		nonNull.Nullable = false
+		return g.schemaType(&nonNull) + " | null"
+	}
+	if len(schema.AllOf) == 1 {
+		return g.typeOf(schema.AllOf[0])
+	}
+	if ref, ok := schema.Extensions["$ref"].(string); ok {
+		return g.refName(ref)
+	}
+	if len(schema.Enum) > 0 {
+		literals := make([]string, 0, len(schema.Enum))
+		for _, value := range schema.Enum {
+			data, _ := json.Marshal(value)
+			literals = append(literals, string(data))
+		}
+		return strings.Join(literals, " | ")
+	}
+
+	switch {
+	case schema.Type.Is(openapi3.TypeString):
+		if schema.Format == "binary" {
+			return "Blob"
+		}
+		return "string"
+	case schema.Type.Is(openapi3.TypeInteger), schema.Type.Is(openapi3.TypeNumber):
+		return "number"
+	case schema.Type.Is(openapi3.TypeBoolean):
+		return "boolean"
+	case schema.Type.Is(openapi3.TypeArray):
+		elem := g.typeOf(schema.Items)
+		if strings.ContainsAny(elem, " |") {
+			return "Array<" + elem + ">"
+		}
+		return elem + "[]"
+	case schema.Type.Is(openapi3.TypeObject):
+		if schema.Properties != nil {
+			var builder strings.Builder
+			builder.WriteString("{ ")
+			names := make([]string, 0, len(schema.Properties))
+			for name := range schema.Properties {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				builder.WriteString(fmt.Sprintf("%s%s: %s; ", tsPropertyName(name), optionalMark(schema.Required, name), g.typeOf(schema.Properties[name])))
+			}
+			builder.WriteString("}")
+			return builder.String()
+		}
+		if schema.AdditionalProperties.Schema != nil {
+			return "Record<string, " + g.typeOf(schema.AdditionalProperties.Schema) + ">"
+		}
+	}
+	return "unknown"
+}
+
+// refName 获取组件引用对应的TypeScript类型名
+func (g *tsGenerator) refName(ref string) string {
+	name := strings.TrimPrefix(ref, "#/components/schemas/")
+	if tsName, ok := g.names[name]; ok {
+		return tsName
+	}
+	return name
+}
+
+// writeOperation 生成单个API的请求参数类型和请求函数
+// 参数按照位置分组为path、query、header和body，cookie由浏览器携带，不生成参数
+func (g *tsGenerator) writeOperation(builder *strings.Builder, method, path string, op *openapi3.Operation) {
+	if op.OperationID == "" || op.Extensions["x-websocket"] == true {
+		return
+	}
+	funcName := g.unique(op.OperationID, "")
+	typeName := g.unique(snakeToPascalCase(op.OperationID)+"Request", "")
+
+	// 按照位置分组参数
+	groups := map[string]*openapi3.Schema{}
+	for _, param := range op.Parameters {
+		p := param.Value
+		if p == nil || p.In == openapi3.ParameterInCookie {
+			continue
+		}
+		if groups[p.In] == nil {
+			groups[p.In] = openapi3.NewObjectSchema()
+			groups[p.In].Properties = openapi3.Schemas{}
+		}
+		schema := openapi3.NewSchema()
+		if p.Schema != nil && p.Schema.Value != nil {
+			copied := *p.Schema.Value
+			schema = &copied
+		}
+		schema.Description = p.Description
+		groups[p.In].Properties[p.Name] = &openapi3.SchemaRef{Value: schema}
+		if p.Required {
+			groups[p.In].Required = append(groups[p.In].Required, p.Name)
+		}
+	}
+
+	// 请求体，multipart和urlencoded表单之外的请求体编码为JSON
+	bodyKind := ""
+	var bodySchema *openapi3.SchemaRef
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		content := op.RequestBody.Value.Content
+		switch {
+		case content.Get("multipart/form-data") != nil:
+			bodyKind, bodySchema = "form", content.Get("multipart/form-data").Schema
+		case content.Get("application/x-www-form-urlencoded") != nil:
+			bodyKind, bodySchema = "urlencoded", content.Get("application/x-www-form-urlencoded").Schema
+		default:
+			bodyKind = "json"
+			if media := content.Get(MIMEJSON); media != nil {
+				bodySchema = media.Schema
+			} else {
+				for _, media := range content {
+					bodySchema = media.Schema
+					break
+				}
+			}
+		}
+	}
+
+	// 响应体，使用状态码最小的2xx响应
+	respType, stream := g.responseType(op)
+
+	hasRequest := len(groups) > 0 || bodyKind != ""
+	if hasRequest {
+		builder.WriteString("\n")
+		writeTSDoc(builder, "", fmt.Sprintf("%s 的请求参数", funcName))
+		builder.WriteString(fmt.Sprintf("export interface %s {\n", typeName))
+		for _, in := range []string{openapi3.ParameterInPath, openapi3.ParameterInQuery, openapi3.ParameterInHeader} {
+			schema := groups[in]
+			if schema == nil {
+				continue
+			}
+			mark := "?"
+			if len(schema.Required) > 0 {
+				mark = ""
+			}
+			builder.WriteString(fmt.Sprintf("  %s%s: {\n", in, mark))
+			g.writeProperties(builder, "    ", schema)
+			builder.WriteString("  };\n")
+		}
+		if bodyKind != "" {
+			builder.WriteString(fmt.Sprintf("  body: %s;\n", g.typeOf(bodySchema)))
+		}
+		builder.WriteString("}\n")
+	}
+
+	// 请求函数
+	builder.WriteString("\n")
+	lines := []string{}
+	if op.Summary != "" {
+		lines = append(lines, op.Summary)
+	}
+	if op.Deprecated {
+		lines = append(lines, "@deprecated")
+	}
+	lines = append(lines, method+" "+path)
+	writeTSDoc(builder, "", strings.Join(lines, "\n"))
+	if hasRequest {
+		builder.WriteString(fmt.Sprintf("export function %s(req: %s, options?: RequestInit): Promise<%s> {\n", funcName, typeName, respType))
+	} else {
+		builder.WriteString(fmt.Sprintf("export function %s(options?: RequestInit): Promise<%s> {\n", funcName, respType))
+	}
+
+	params := []string{}
+	if groups[openapi3.ParameterInQuery] != nil {
+		params = append(params, "query: req.query")
+	}
+	if groups[openapi3.ParameterInHeader] != nil {
+		params = append(params, "header: req.header")
+	}
+	if bodyKind != "" {
+		params = append(params, bodyKind+": req.body")
+	}
+	if stream {
+		params = append(params, "stream: true")
+	}
+	paramsExpr := "{}"
+	if len(params) > 0 {
+		paramsExpr = "{ " + strings.Join(params, ", ") + " }"
+	}
+	builder.WriteString(fmt.Sprintf("  return request<%s>(%q, %s, %s, options);\n", respType, method, tsPathTemplate(path), paramsExpr))
+	builder.WriteString("}\n")
+}
+
+// responseType 获取状态码最小的2xx响应的TypeScript类型，事件流返回原始的Response
+func (g *tsGenerator) responseType(op *openapi3.Operation) (string, bool) {
+	codes := make([]int, 0)
+	for code := range op.Responses.Map() {
+		if n, err := strconv.Atoi(code); err == nil && n >= http.StatusOK && n < http.StatusMultipleChoices {
+			codes = append(codes, n)
+		}
+	}
+	sort.Ints(codes)
+	for _, code := range codes {
+		resp := op.Responses.Value(strconv.Itoa(code))
+		if resp == nil || resp.Value == nil || len(resp.Value.Content) == 0 {
+			continue
+		}
+		if resp.Value.Content.Get(ContentTypeEventStream) != nil {
+			return "Response", true
+		}
+		if media := resp.Value.Content.Get(MIMEJSON); media != nil {
+			return g.typeOf(media.Schema), false
+		}
+		for _, media := range resp.Value.Content {
+			return g.typeOf(media.Schema), false
+		}
+	}
+	return "void", false
+}
+
+// tsPathTemplate 将{param}和*param格式的路径参数转换为模板字符串
+func tsPathTemplate(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		switch {
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"):
+			segments[i] = fmt.Sprintf("${pathParam(req.path%s)}", tsPropertyAccess(segment[1:len(segment)-1]))
+		case strings.HasPrefix(segment, "*"):
+			segments[i] = fmt.Sprintf("${pathParam(req.path%s, true)}", tsPropertyAccess(segment[1:]))
+		}
+	}
+	return "`" + strings.Join(segments, "/") + "`"
+}
+
+// tsPropertyName 获取属性名，非标识符的属性名使用引号
+func tsPropertyName(name string) string {
+	if tsIdentifier.MatchString(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
+
+// tsPropertyAccess 获取属性访问表达式
+func tsPropertyAccess(name string) string {
+	if tsIdentifier.MatchString(name) {
+		return "." + name
+	}
+	return "[" + strconv.Quote(name) + "]"
+}
+
+func optionalMark(required []string, name string) string {
+	for _, r := range required {
+		if r == name {
+			return ""
+		}
+	}
+	return "?"
+}
+
+// writeTSDoc 生成JSDoc注释
+func writeTSDoc(builder *strings.Builder, indent, text string) {
+	if text == "" {
+		return
+	}
+	text = strings.ReplaceAll(text, "*/", "*\\/")
+	lines := strings.Split(text, "\n")
+	if len(lines) == 1 {
+		builder.WriteString(fmt.Sprintf("%s/** %s */\n", indent, text))
+		return
+	}
+	builder.WriteString(indent + "/**\n")
+	for _, line := range lines {
+		builder.WriteString(fmt.Sprintf("%s * %s\n", indent, line))
+	}
+	builder.WriteString(indent + " */\n")
+}
+
+// tsRuntime 生成的TypeScript文件中的运行时代码
+const tsRuntime = `/** 客户端配置，baseURL为服务地址，headers为每个请求都会携带的请求头 */
+export const config: {
+  baseURL: string;
+  headers: Record<string, string>;
+  fetch: (input: string, init: RequestInit) => Promise<Response>;
+} = {
+  baseURL: "",
+  headers: {},
+  fetch: (input, init) => fetch(input, init),
+};
+
+/** 服务端返回的错误，status为HTTP状态码，body为原始响应体 */
+export class EasyGinError extends Error {
+  status: number;
+  desc: string;
+  body: unknown;
+
+  constructor(status: number, message: string, desc: string, body: unknown) {
+    super(message);
+    this.name = "EasyGinError";
+    this.status = status;
+    this.desc = desc;
+    this.body = body;
+  }
+}
+
+interface RequestParams {
+  query?: object;
+  header?: object;
+  json?: unknown;
+  form?: object;
+  urlencoded?: object;
+  stream?: boolean;
+}
+
+function each(value: unknown, fn: (v: string | Blob) => void): void {
+  if (value === undefined || value === null) {
+    return;
+  }
+  if (Array.isArray(value)) {
+    value.forEach((v) => each(v, fn));
+    return;
+  }
+  fn(value instanceof Blob ? value : String(value));
+}
+
+function pathParam(value: unknown, wildcard = false): string {
+  const s = String(value);
+  if (wildcard) {
+    return s.replace(/^\//, "").split("/").map(encodeURIComponent).join("/");
+  }
+  return encodeURIComponent(s);
+}
+
+async function decodeError(resp: Response): Promise<EasyGinError> {
+  const text = await resp.text();
+  let body: { msg?: string; desc?: string; title?: string; detail?: string };
+  try {
+    body = JSON.parse(text) ?? {};
+  } catch {
+    return new EasyGinError(resp.status, resp.statusText, text, text);
+  }
+  if (body.title) {
+    return new EasyGinError(resp.status, body.title, body.detail ?? "", body);
+  }
+  return new EasyGinError(resp.status, body.msg ?? resp.statusText, body.desc ?? "", body);
+}
+
+async function request<T>(method: string, path: string, params: RequestParams, options?: RequestInit): Promise<T> {
+  const query = new URLSearchParams();
+  for (const [name, value] of Object.entries(params.query ?? {})) {
+    each(value, (v) => query.append(name, String(v)));
+  }
+
+  const headers = new Headers(config.headers);
+  new Headers(options?.headers).forEach((value, name) => headers.set(name, value));
+  for (const [name, value] of Object.entries(params.header ?? {})) {
+    each(value, (v) => headers.append(name, String(v)));
+  }
+  headers.set("Accept", params.stream ? "text/event-stream" : "application/json");
+
+  let body: BodyInit | undefined;
+  if (params.json !== undefined) {
+    headers.set("Content-Type", "application/json");
+    body = JSON.stringify(params.json);
+  } else if (params.form !== undefined) {
+    const form = new FormData();
+    for (const [name, value] of Object.entries(params.form)) {
+      each(value, (v) => form.append(name, v));
+    }
+    body = form;
+  } else if (params.urlencoded !== undefined) {
+    const form = new URLSearchParams();
+    for (const [name, value] of Object.entries(params.urlencoded)) {
+      each(value, (v) => form.append(name, String(v)));
+    }
+    body = form;
+  }
+
+  const search = query.toString();
+  const url = config.baseURL.replace(/\/$/, "") + path + (search ? "?" + search : "");
+  const resp = await config.fetch(url, { ...options, method, headers, body });
+  if (!resp.ok) {
+    throw await decodeError(resp);
+  }
+  if (params.stream) {
+    return resp as T;
+  }
+  const text = await resp.text();
+  return (text ? JSON.parse(text) : undefined) as T;
+}
+`
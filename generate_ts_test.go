@@ -0,0 +1,71 @@
+package easygin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateTypeScript(t *testing.T) {
+	root := NewRouterGroup("/api")
+	root.RegisterAPI(&TestClientAPI{})
+	root.RegisterAPI(&TestTSAPI{})
+	root.RegisterAPI(&testWebSocketAPI{})
+
+	file := filepath.Join(t.TempDir(), "client.ts")
+	if err := GenerateTypeScript(file, root); err != nil {
+		t.Fatalf("GenerateTypeScript returned error: %v", err)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("read generated file failed: %v", err)
+	}
+	code := string(data)
+
+	for _, expected := range []string{
+		// 类型定义使用Go类型名，全局对象重名时添加包名前缀
+		"export interface TestClientResp {",
+		"export interface EasyginError {",
+		// omitempty对应可选属性，",string"对应string类型
+		"  count: string;\n",
+		"  note?: string;\n",
+		"  tags: string[];\n",
+		// 指针字段可以为null
+		"  parent: TestClientBody | null;\n",
+		// 参数按照位置分组
+		"export interface TestClientAPIRequest {\n  path: {\n    id: number;\n  };\n  query?: {\n    tag?: string;\n  };",
+		"export function testClientAPI(req: TestClientAPIRequest, options?: RequestInit): Promise<TestClientResp> {",
+		"return request<TestClientResp>(\"POST\", `/api/items/${pathParam(req.path.id)}`, { query: req.query, header: req.header, json: req.body }, options);",
+		"export function testTSAPI(req: TestTSAPIRequest, options?: RequestInit): Promise<void> {",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Fatalf("expected generated TypeScript to contain %q, got:\n%s", expected, code)
+		}
+	}
+	if strings.Contains(code, "/echo") {
+		t.Fatalf("expected websocket api to be skipped, got:\n%s", code)
+	}
+}
+
+// TestTSAPI 用于测试json标签生成的TypeScript属性
+type TestTSAPI struct {
+	MethodPut
+	Body TestTSBody `in:"body"`
+}
+
+type TestTSBody struct {
+	Count  int             `json:"count,string"`
+	Note   string          `json:"note,omitempty"`
+	Tags   []string        `json:"tags"`
+	Parent *TestClientBody `json:"parent"`
+}
+
+func (TestTSAPI) Path() string {
+	return "/ts"
+}
+
+func (api *TestTSAPI) Output(ctx context.Context) (any, error) {
+	return nil, nil
+}
@@ -2,6 +2,7 @@ package easygin
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -16,7 +17,6 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-
 // fieldInfo 存储字段的元信息
 type fieldInfo struct {
 	index    []int // 索引路径
@@ -24,6 +24,7 @@ type fieldInfo struct {
 	tagType  string
 	tagName  string
 	tagNames []string
+	validate string // validate标签内容
 }
 
 // structFields 缓存结构体的字段信息
@@ -34,22 +35,12 @@ func handleError(c *gin.Context, err error) {
 	// 将错误添加到 gin.Context 的 Errors 中
 	_ = c.Error(err)
 
-	if errorHttp, ok := err.(ErrorHttp); ok {
-		resp := gin.H{
-			"code": errorHttp.StatusCode(),
-			"msg":  errorHttp.Error(),
-			"desc": errorHttp.Desc(),
-		}
-		c.AbortWithStatusJSON(errorHttp.StatusCode(), resp)
-		return
-	}
+	renderError(c, err)
+}
 
-	resp := gin.H{
-		"code": 500,
-		"msg":  err.Error(),
-		"desc": "Internal Server Error",
-	}
-	c.AbortWithStatusJSON(500, resp)
+// renderError 使用上下文中的ErrorRenderer渲染错误响应
+func renderError(c *gin.Context, err error) {
+	ErrorRendererFromContext(c.Request.Context()).RenderError(c, err)
 }
 
 // parseTime 统一处理时间格式解析
@@ -191,6 +182,7 @@ func collectFields(t reflect.Type, indexPrefix []int, fields *[]fieldInfo) {
 					tagType:  tag,
 					tagName:  tagNames[0],
 					tagNames: tagNames,
+					validate: validateTag(field),
 				})
 			}
 		}
@@ -241,11 +233,24 @@ func getOrInitFieldByIndex(v reflect.Value, index []int) (reflect.Value, error)
 
 // bindParams 统一处理参数绑定逻辑
 func bindParams(c *gin.Context, h RouterHandler) (RouterHandler, error) {
+	// NewMiddleware创建的中间件绑定内部中间件的参数
+	if wrapper, ok := h.(middlewareWrapper); ok {
+		return wrapper.bind(c)
+	}
+	newHandler, err := bindValue(c, h)
+	if err != nil {
+		return nil, err
+	}
+	return newHandler.(RouterHandler), nil
+}
+
+// bindValue 创建与h类型相同的结构体实例并绑定参数
+func bindValue(c *gin.Context, h any) (any, error) {
 	// 获取接口的真实类型
 	handlerType := reflect.TypeOf(h)
 
 	// 创建新的结构体实例
-	newHandler := reflect.New(handlerType.Elem()).Interface().(RouterHandler)
+	newHandler := reflect.New(handlerType.Elem()).Interface()
 	if bindParameters, ok := newHandler.(WithBindParameters); ok {
 		if err := bindParameters.EasyGinBindParameters(c); err != nil {
 			return nil, err
@@ -258,6 +263,9 @@ func bindParams(c *gin.Context, h RouterHandler) (RouterHandler, error) {
 	// 获取缓存的字段信息
 	fields := getStructFields(handlerType.Elem())
 
+	// 收集所有字段的校验错误，绑定完成后统一返回
+	verrs := &ValidationError{}
+
 	// 遍历字段信息
 	for _, info := range fields {
 		// 使用FieldByIndex获取嵌套字段
@@ -286,7 +294,28 @@ func bindParams(c *gin.Context, h RouterHandler) (RouterHandler, error) {
 				}
 
 				// 使用decodeMultipartForm解析表单数据
-				if err := decodeMultipartForm(c.Request.MultipartForm, targetValue.Interface()); err != nil {
+				if err := verrs.Collect(decodeMultipartForm(c.Request.MultipartForm, targetValue.Interface())); err != nil {
+					return nil, err
+				}
+
+				continue
+			}
+			if mime == "urlencoded" {
+				if err := c.Request.ParseForm(); err != nil {
+					return nil, fmt.Errorf("parse urlencoded form failed: %v", err)
+				}
+
+				targetValue := fieldValue
+				if fieldValue.Kind() == reflect.Ptr {
+					if fieldValue.IsNil() {
+						fieldValue.Set(reflect.New(fieldValue.Type().Elem()))
+					}
+				} else {
+					targetValue = fieldValue.Addr()
+				}
+
+				// urlencoded表单没有文件字段，只解析请求体中的表单值
+				if err := verrs.Collect(decodeForm(c.Request.PostForm, nil, targetValue.Interface())); err != nil {
 					return nil, err
 				}
 
@@ -301,14 +330,20 @@ func bindParams(c *gin.Context, h RouterHandler) (RouterHandler, error) {
 			} else {
 				target = fieldValue.Addr()
 			}
-			if err := decodeJSON(c.Request.Body, target.Interface()); err != nil {
-				return nil, fmt.Errorf("invalid body parameter: %v", err)
+			// 根据Content-Type选择Codec，mime标签限制可以使用的Codec
+			codec, err := RequestCodec(c, target.Interface(), parseCodecTag(mime)...)
+			if err != nil {
+				return nil, err
+			}
+			// 请求体解析失败同样作为字段错误收集，与其他参数的错误一并返回
+			if err := verrs.Collect(decodeBody(codec, c.Request.Body, target.Interface())); err != nil {
+				verrs.Add("body", "", err.Error(), "")
 			}
 			continue
 		}
 
-		// 处理query、path和header参数
-		if info.tagType == "query" || info.tagType == "path" || info.tagType == "header" {
+		// 处理query、path、header和cookie参数
+		if info.tagType == "query" || info.tagType == "path" || info.tagType == "header" || info.tagType == "cookie" {
 			// 跳过未命名的参数
 			if info.tagName == "" || info.tagName == "-" {
 				continue
@@ -322,11 +357,15 @@ func bindParams(c *gin.Context, h RouterHandler) (RouterHandler, error) {
 				val = c.Param(info.tagName)
 			case "header":
 				val = c.GetHeader(info.tagName)
+			case "cookie":
+				// cookie不存在时返回空字符串，按照缺失参数处理
+				val, _ = c.Cookie(info.tagName)
 			}
 
 			if val == "" {
 				if !slices.Contains(info.tagNames, "omitempty") {
-					return nil, fmt.Errorf("missing required parameter '%s' in %s", info.tagName, info.tagType)
+					verrs.Add(info.tagType, info.tagName, reasonMissingParameter, "")
+					continue
 				}
 
 				defaultValue := info.field.Tag.Get("default")
@@ -337,17 +376,35 @@ func bindParams(c *gin.Context, h RouterHandler) (RouterHandler, error) {
 			}
 
 			if err := setFieldValue(fieldValue, val, info.tagName, info.field.Type); err != nil {
-				return nil, err
+				verrs.Add(info.tagType, info.tagName, reasonInvalidValue, fieldErrorValue(info.tagType, val))
+				continue
+			}
+
+			// 按照validate标签校验参数值
+			if info.validate != "" {
+				if err := ValidateValue(fieldValue.Interface(), info.validate); err != nil {
+					verrs.Add(info.tagType, info.tagName, err.Error(), fieldErrorValue(info.tagType, val))
+				}
 			}
 			continue
 		}
 	}
 
+	if err := verrs.Err(); err != nil {
+		return nil, err
+	}
+
 	return newHandler, nil
 }
 
 // renderAPI 处理API
 func renderAPI(h RouterHandler, handlerName string) gin.HandlerFunc {
+	// 启动时校验mime标签，声明了未注册的Codec时直接panic
+	if err := validateCodecTags(reflect.TypeOf(h)); err != nil {
+		panic(fmt.Sprintf("%s: %v", handlerName, err))
+	}
+	responseCodecs := parseCodecTag(methodCodecTag(h))
+
 	return func(c *gin.Context) {
 		// 将handlerName存入context
 		c.Request = c.Request.WithContext(ContextWithHandlerName(c.Request.Context(), handlerName))
@@ -399,6 +456,10 @@ func renderAPI(h RouterHandler, handlerName string) gin.HandlerFunc {
 			}
 		case string:
 			c.String(code, v)
+		case EventStream:
+			renderEventStream(c, code, &v)
+		case *EventStream:
+			renderEventStream(c, code, v)
 		case AttachmentFromFile:
 			c.Header("Content-Disposition", fmt.Sprintf("%s; filename=%s", v.Disposition, v.Filename))
 			c.Data(code, v.ContentType, v.Content)
@@ -420,7 +481,13 @@ func renderAPI(h RouterHandler, handlerName string) gin.HandlerFunc {
 				_ = closer.Close()
 			}
 		default:
-			c.JSON(code, output)
+			// 根据Accept选择Codec编码响应体
+			codec, err := ResponseCodec(c, output, responseCodecs...)
+			if err != nil {
+				handleError(c, err)
+				return
+			}
+			c.Render(code, codecRender{codec: codec, data: output})
 		}
 	}
 }
@@ -437,8 +504,12 @@ func renderMiddleware(h RouterHandler, handlerName string) gin.HandlerFunc {
 			return
 		}
 
-		if key, ok := h.(ContextKey); ok {
-			c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), key.ContextKey(), output))
+		if key, ok := handlerValue(h).(ContextKey); ok {
+			c.Request = c.Request.WithContext(ContextWithMiddlewareOutput(c.Request.Context(), key, output))
+		}
+		// NewMiddleware创建的中间件按照输出类型存储，供Dep字段获取
+		if wrapper, ok := h.(middlewareWrapper); ok {
+			c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), middlewareOutputKey{wrapper.outputType()}, output))
 		}
 
 		c.Next()
@@ -457,9 +528,9 @@ func renderGinHandler(h GinHandler, handlerName string) gin.HandlerFunc {
 // handleRouter 处理通用的RouterHandler逻辑，包括参数绑定和调用Handle方法
 func handleRouter(c *gin.Context, h RouterHandler) (any, error) {
 	// 绑定参数
-	newHandler, err := bindParams(c, h)
+	newHandler, err := bindHandler(c, h)
 	if err != nil {
-		return nil, NewError(http.StatusBadRequest, err.Error(), "invalid parameters")
+		return nil, err
 	}
 
 	// 将gin.Context添加到context中
@@ -467,6 +538,24 @@ func handleRouter(c *gin.Context, h RouterHandler) (any, error) {
 	return newHandler.Output(ContextWithGinContext(c.Request.Context(), c))
 }
 
+// bindHandler 绑定参数和依赖并返回新的处理器，绑定失败时返回可以直接渲染的错误
+func bindHandler(c *gin.Context, h RouterHandler) (RouterHandler, error) {
+	newHandler, err := bindParams(c, h)
+	if err != nil {
+		// 参数校验错误和已经带有状态码的错误（如415）直接返回，保留原有的状态码和错误信息
+		var errorHttp ErrorHttp
+		if errors.As(err, &errorHttp) {
+			return nil, errorHttp
+		}
+		return nil, NewError(http.StatusBadRequest, err.Error(), "invalid parameters")
+	}
+	// 从中间件链中获取Dep字段声明的依赖
+	if err := injectDependencies(c.Request.Context(), handlerValue(newHandler)); err != nil {
+		return nil, err
+	}
+	return newHandler, nil
+}
+
 type Disposition string
 
 const (
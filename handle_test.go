@@ -3,9 +3,12 @@ package easygin
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"slices"
 	"strings"
 	"testing"
 
@@ -77,6 +80,24 @@ func TestBindParamsInitializesEmbeddedPointerFields(t *testing.T) {
 		}
 	})
 
+	t.Run("Cookie", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/cookie", nil)
+		req.AddCookie(&http.Cookie{Name: "session", Value: "s%201"})
+		req.AddCookie(&http.Cookie{Name: "page", Value: "3"})
+
+		bound := bindHandlerForTest(t, &TestCookieHandler{}, req, nil).(*TestCookieHandler)
+
+		if bound.Session != "s 1" {
+			t.Fatalf("expected unescaped session cookie 's 1', got %q", bound.Session)
+		}
+		if bound.Page != 3 {
+			t.Fatalf("expected page cookie 3, got %d", bound.Page)
+		}
+		if bound.Theme != "light" {
+			t.Fatalf("expected default theme 'light', got %q", bound.Theme)
+		}
+	})
+
 	t.Run("BodyJSONDefaults", func(t *testing.T) {
 		prev := HandleBodyJsonOmitEmptyAndDefault()
 		SetHandleBodyJsonOmitEmptyAndDefault(true)
@@ -147,6 +168,33 @@ func TestBindParamsInitializesEmbeddedPointerFields(t *testing.T) {
 		}
 	})
 
+	t.Run("URLEncodedBody", func(t *testing.T) {
+		form := url.Values{"grant_type": {"password"}, "scope": {"read", "write"}, "expires": {"60"}}
+		req := httptest.NewRequest(http.MethodPost, "/token", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		bound := bindHandlerForTest(t, &TestURLEncodedHandler{}, req, nil).(*TestURLEncodedHandler)
+
+		if bound.Body.GrantType != "password" || bound.Body.Expires != 60 {
+			t.Fatalf("expected urlencoded fields to be bound, got %+v", bound.Body)
+		}
+		if len(bound.Body.Scope) != 2 || bound.Body.Scope[1] != "write" {
+			t.Fatalf("expected repeated scope values, got %v", bound.Body.Scope)
+		}
+
+		req = httptest.NewRequest(http.MethodPost, "/token", strings.NewReader("expires=soon"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(recorder)
+		ctx.Request = req
+
+		_, err := bindParams(ctx, &TestURLEncodedHandler{})
+		verrs, ok := err.(*ValidationError)
+		if !ok || len(verrs.Errors) != 2 {
+			t.Fatalf("expected missing grant_type and invalid expires, got %v", err)
+		}
+	})
+
 	t.Run("MultipartFileFields", func(t *testing.T) {
 		body := &bytes.Buffer{}
 		writer := multipart.NewWriter(body)
@@ -195,6 +243,104 @@ func TestBindParamsInitializesEmbeddedPointerFields(t *testing.T) {
 	})
 }
 
+func TestBindParamsValidateTags(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("CollectsAllFailures", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/validate?size=0&order=random", strings.NewReader(`{"email":"not-an-email","items":[{"code":"ab"}]}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		recorder := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(recorder)
+		ctx.Request = req
+
+		_, err := bindParams(ctx, &TestValidateHandler{})
+		verrs, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("expected *ValidationError, got %T: %v", err, err)
+		}
+
+		expected := map[string]string{
+			"query size":         "must be at least 1",
+			"query order":        "must be one of [asc, desc]",
+			"body email":         "must be a valid email",
+			"body items[0].code": "length must be exactly 3",
+		}
+		if len(verrs.Errors) != len(expected) {
+			t.Fatalf("expected %d field errors, got %+v", len(expected), verrs.Errors)
+		}
+		for _, fe := range verrs.Errors {
+			if reason := expected[fe.In+" "+fe.Name]; reason != fe.Reason {
+				t.Fatalf("unexpected field error %+v", fe)
+			}
+		}
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/validate?order=asc", strings.NewReader(`{"email":"a@example.com","items":[{"code":"abc"}]}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		bound := bindHandlerForTest(t, &TestValidateHandler{}, req, nil).(*TestValidateHandler)
+		if bound.Size != 10 {
+			t.Fatalf("expected default size 10, got %d", bound.Size)
+		}
+	})
+}
+
+func TestBindParamsCollectsBindingErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	req := httptest.NewRequest(http.MethodPost, "/items/abc?page=x", strings.NewReader(`{"name":`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Retry", "Bearer secret")
+	req.AddCookie(&http.Cookie{Name: "seq", Value: "session-secret"})
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "id", Value: "abc"}}
+
+	_, err := bindParams(ctx, &TestBindingErrorsHandler{})
+	verrs, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("expected *ValidationError, got %T: %v", err, err)
+	}
+
+	expected := []FieldError{
+		{In: "path", Name: "id", Reason: reasonInvalidValue, Value: "abc"},
+		{In: "header", Name: "X-Token", Reason: reasonMissingParameter},
+		// header和cookie的原始值可能是凭证，不回显
+		{In: "header", Name: "X-Retry", Reason: reasonInvalidValue},
+		{In: "cookie", Name: "seq", Reason: reasonInvalidValue},
+		{In: "query", Name: "page", Reason: reasonInvalidValue, Value: "x"},
+	}
+	if len(verrs.Errors) != len(expected)+1 {
+		t.Fatalf("expected %d field errors, got %+v", len(expected)+1, verrs.Errors)
+	}
+	for _, fe := range expected {
+		if !slices.Contains(verrs.Errors, fe) {
+			t.Fatalf("expected field error %+v, got %+v", fe, verrs.Errors)
+		}
+	}
+	if !slices.ContainsFunc(verrs.Errors, func(fe FieldError) bool {
+		return fe.In == "body" && fe.Name == "" && strings.HasPrefix(fe.Reason, "parse json failed")
+	}) {
+		t.Fatalf("expected body parse error, got %+v", verrs.Errors)
+	}
+
+	handleError(ctx, err)
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", recorder.Code)
+	}
+	var resp ValidationErrorResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response failed: %v", err)
+	}
+	if resp.Code != http.StatusBadRequest || len(resp.Errors) != len(verrs.Errors) {
+		t.Fatalf("unexpected response body %s", recorder.Body.String())
+	}
+}
+
 func bindHandlerForTest(t *testing.T, handler RouterHandler, req *http.Request, customize func(*gin.Context)) RouterHandler {
 	t.Helper()
 
@@ -307,6 +453,49 @@ type TestMultipartFilesBody struct {
 	Multi  []*multipart.FileHeader `name:"files"`
 }
 
+type TestURLEncodedHandler struct {
+	Body TestURLEncodedBody `in:"body" mime:"urlencoded"`
+}
+
+type TestURLEncodedBody struct {
+	GrantType string   `name:"grant_type"`
+	Scope     []string `name:"scope,omitempty"`
+	Expires   int      `name:"expires,omitempty"`
+}
+
+type TestCookieHandler struct {
+	Session string `in:"cookie" name:"session"`
+	Page    int    `in:"cookie" name:"page,omitempty"`
+	Theme   string `in:"cookie" name:"theme,omitempty" default:"light"`
+}
+
+type TestBindingErrorsHandler struct {
+	MethodPost
+	ID    int    `in:"path" name:"id"`
+	Token string `in:"header" name:"X-Token"`
+	Retry int    `in:"header" name:"X-Retry,omitempty"`
+	Seq   int    `in:"cookie" name:"seq,omitempty"`
+	Page  int    `in:"query" name:"page"`
+	Body  struct {
+		Name string `json:"name"`
+	} `in:"body"`
+}
+
+type TestValidateHandler struct {
+	Size  int                 `in:"query" name:"size,omitempty" default:"10" validate:"min=1,max=100"`
+	Order string              `in:"query" name:"order" validate:"enum=asc|desc"`
+	Body  TestValidatePayload `in:"body"`
+}
+
+type TestValidatePayload struct {
+	Email string             `json:"email" validate:"email"`
+	Items []TestValidateItem `json:"items" validate:"min=1"`
+}
+
+type TestValidateItem struct {
+	Code string `json:"code" validate:"len=3"`
+}
+
 func (h *TestEmbeddedPointerHandler) Output(ctx context.Context) (any, error) {
 	return nil, nil
 }
@@ -334,3 +523,23 @@ func (h *TestOptionalQueryHandler) Output(ctx context.Context) (any, error) {
 func (h *TestMultipartFilesHandler) Output(ctx context.Context) (any, error) {
 	return nil, nil
 }
+
+func (h *TestURLEncodedHandler) Output(ctx context.Context) (any, error) {
+	return nil, nil
+}
+
+func (h *TestCookieHandler) Output(ctx context.Context) (any, error) {
+	return nil, nil
+}
+
+func (h *TestBindingErrorsHandler) Path() string {
+	return "/items/:id"
+}
+
+func (h *TestBindingErrorsHandler) Output(ctx context.Context) (any, error) {
+	return nil, nil
+}
+
+func (h *TestValidateHandler) Output(ctx context.Context) (any, error) {
+	return nil, nil
+}
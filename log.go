@@ -66,6 +66,14 @@ func middleLogger(serviceName string) gin.HandlerFunc {
 				"status", c.Writer.Status(), // HTTP 状态码
 			}
 
+			// 事件流的耗时包含整个连接的持续时间，额外记录首字节耗时和事件数量
+			if stats := eventStreamStatsFromContext(c.Request.Context()); stats != nil {
+				keyAndValues = append(keyAndValues,
+					"ttfb", stats.startedAt.Sub(startAt), // 响应头刷新到客户端的耗时
+					"events", stats.events, // 已输出的事件数量
+				)
+			}
+
 			// 获取请求处理过程中可能发生的错误
 			var err error
 			errs := c.Errors.ByType(gin.ErrorTypePrivate)
@@ -25,12 +25,7 @@ func init() {
 // 也可以不使用该方法，自定义创建全局追踪器
 func InitGlobalTracerProvider(serviceName string, customExporters ...sdktrace.SpanExporter) {
 	// 尝试关闭现有的 TracerProvider 以释放资源
-	// 使用安全的类型断言
-	if currentProvider := otel.GetTracerProvider(); currentProvider != nil {
-		if provider, ok := currentProvider.(*sdktrace.TracerProvider); ok {
-			_ = provider.Shutdown(context.Background())
-		}
-	}
+	_ = shutdownGlobalTracerProvider(context.Background())
 
 	// 设置全局传播器为W3C Trace Context标准
 	// 这确保了追踪上下文可以在不同服务之间正确传递
@@ -71,6 +66,16 @@ func InitGlobalTracerProvider(serviceName string, customExporters ...sdktrace.Sp
 	otel.SetTracerProvider(tp)
 }
 
+// shutdownGlobalTracerProvider 关闭全局 TracerProvider，导出批处理器中剩余的 span
+// 如果全局 TracerProvider 不是 sdktrace.TracerProvider，则不做任何处理
+func shutdownGlobalTracerProvider(ctx context.Context) error {
+	// 使用安全的类型断言
+	if provider, ok := otel.GetTracerProvider().(*sdktrace.TracerProvider); ok {
+		return provider.Shutdown(ctx)
+	}
+	return nil
+}
+
 // InjectTraceParent 注入 trace parent 到 header 中
 // 这个函数用于在发起 HTTP 请求时，将当前的追踪上下文注入到请求头中
 // 参数:
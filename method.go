@@ -1,6 +1,9 @@
 package easygin
 
-import "net/http"
+import (
+	"context"
+	"net/http"
+)
 
 type MethodGet struct{}
 
@@ -61,3 +64,15 @@ type MethodAny struct{}
 func (MethodAny) Method() string {
 	return "ANY"
 }
+
+// MethodWebSocket WebSocket路由，以GET方法注册，API需要同时实现WebSocketHandler接口
+type MethodWebSocket struct{}
+
+func (MethodWebSocket) Method() string {
+	return http.MethodGet
+}
+
+// Output WebSocket API通过ServeWebSocket处理连接，不会调用Output
+func (MethodWebSocket) Output(ctx context.Context) (any, error) {
+	return nil, errWebSocketOutput
+}
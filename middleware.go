@@ -0,0 +1,247 @@
+package easygin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware 定义了输出类型为T的中间件
+// 通过NewMiddleware注册到路由组后，Output的返回值按照类型T存入上下文，
+// 路由组及其子路由组中的API通过Dep[T]字段获取，不需要手写类型断言
+//
+//	func (m *MustAuth) Output(ctx context.Context) (*UserInfo, error) {
+//		...
+//	}
+//
+//	var RouterRoot = easygin.NewRouterGroup("/user", easygin.NewMiddleware[*UserInfo](&MustAuth{}))
+type Middleware[T any] interface {
+	Output(ctx context.Context) (T, error)
+}
+
+// NewMiddleware 将Middleware[T]转换为可以注册到路由组的中间件
+// 参数绑定、OpenAPI文档和静态参数绑定方法的生成都作用于传入的中间件，与普通中间件一致
+// 同一条中间件链中不能有两个输出类型相同的中间件
+func NewMiddleware[T any](middleware Middleware[T]) RouterHandler {
+	return &typedMiddleware[T]{middleware: middleware}
+}
+
+// middlewareWrapper 由NewMiddleware创建的中间件实现
+type middlewareWrapper interface {
+	RouterHandler
+	unwrap() any                                // 获取内部的中间件
+	outputType() reflect.Type                   // 获取中间件的输出类型
+	bind(c *gin.Context) (RouterHandler, error) // 绑定内部中间件的参数
+}
+
+// typedMiddleware 将Middleware[T]转换为RouterHandler
+type typedMiddleware[T any] struct {
+	middleware Middleware[T]
+}
+
+func (m *typedMiddleware[T]) Output(ctx context.Context) (any, error) {
+	output, err := m.middleware.Output(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// nil输出（包括nil指针、map等）存入上下文后，API通过Dep.Get()获取时会panic，返回错误指出对应的中间件
+	if isNilOutput(output) {
+		return nil, fmt.Errorf("middleware %T returned nil %s without error", m.middleware, m.outputType())
+	}
+	return output, nil
+}
+
+// isNilOutput 判断中间件的输出是否为nil，包括接口中的nil指针、map、切片、函数和通道
+func isNilOutput(output any) bool {
+	if output == nil {
+		return true
+	}
+	v := reflect.ValueOf(output)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
+
+func (m *typedMiddleware[T]) unwrap() any {
+	return m.middleware
+}
+
+func (m *typedMiddleware[T]) outputType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func (m *typedMiddleware[T]) bind(c *gin.Context) (RouterHandler, error) {
+	bound, err := bindValue(c, m.middleware)
+	if err != nil {
+		return nil, err
+	}
+	return &typedMiddleware[T]{middleware: bound.(Middleware[T])}, nil
+}
+
+// handlerValue 获取处理器的实际值，NewMiddleware创建的中间件返回内部的中间件
+// 用于反射处理器的类型、字段和标签
+func handlerValue(h RouterHandler) any {
+	if wrapper, ok := h.(middlewareWrapper); ok {
+		return wrapper.unwrap()
+	}
+	return h
+}
+
+// middlewareOutputKey NewMiddleware创建的中间件的输出在上下文中的键
+type middlewareOutputKey struct {
+	t reflect.Type
+}
+
+// Dep 声明API依赖的中间件输出，参数绑定完成后从中间件链中获取类型为T的输出
+// 启动时检查中间件链中是否有NewMiddleware注册的输出类型为T的中间件，没有时panic
+//
+//	type GetUser struct {
+//		easygin.MethodGet
+//		User easygin.Dep[*middleware.UserInfo]
+//	}
+//
+//	func (req *GetUser) Output(ctx context.Context) (any, error) {
+//		user := req.User.Get()
+//		...
+//	}
+type Dep[T any] struct {
+	value T
+}
+
+// NewDep 创建值为value的依赖，用于单元测试中直接调用API的Output
+func NewDep[T any](value T) Dep[T] {
+	return Dep[T]{value: value}
+}
+
+// Get 获取中间件的输出
+func (d Dep[T]) Get() T {
+	return d.value
+}
+
+func (d *Dep[T]) set(value any) {
+	d.value = value.(T)
+}
+
+func (Dep[T]) dependencyType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// dependency 由*Dep[T]实现，用于反射识别依赖字段
+type dependency interface {
+	set(value any)
+	dependencyType() reflect.Type
+}
+
+var (
+	dependencyInterface = reflect.TypeOf((*dependency)(nil)).Elem()
+	// dependencyFieldsCache 缓存结构体类型中依赖字段的索引
+	dependencyFieldsCache sync.Map
+)
+
+// dependencyField 依赖字段的元信息
+type dependencyField struct {
+	index []int
+	name  string
+	t     reflect.Type // 依赖的中间件输出类型
+}
+
+// dependencyFields 获取结构体中的依赖字段，包括嵌入结构体中的字段
+func dependencyFields(t reflect.Type) []dependencyField {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	if cached, ok := dependencyFieldsCache.Load(t); ok {
+		return cached.([]dependencyField)
+	}
+
+	fields := make([]dependencyField, 0)
+	collectDependencyFields(t, nil, &fields)
+	dependencyFieldsCache.Store(t, fields)
+	return fields
+}
+
+// collectDependencyFields 递归收集依赖字段
+func collectDependencyFields(t reflect.Type, indexPrefix []int, fields *[]dependencyField) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		index := append(append([]int{}, indexPrefix...), i)
+
+		if reflect.PointerTo(field.Type).Implements(dependencyInterface) {
+			*fields = append(*fields, dependencyField{
+				index: index,
+				name:  field.Name,
+				t:     reflect.New(field.Type).Interface().(dependency).dependencyType(),
+			})
+			continue
+		}
+
+		// 处理嵌入字段（支持指针形式）
+		if field.Anonymous && field.Tag.Get("in") == "" {
+			embeddedType := field.Type
+			if embeddedType.Kind() == reflect.Ptr {
+				embeddedType = embeddedType.Elem()
+			}
+			if embeddedType.Kind() == reflect.Struct {
+				collectDependencyFields(embeddedType, index, fields)
+			}
+		}
+	}
+}
+
+// injectDependencies 从上下文中获取中间件的输出并填充处理器的依赖字段
+func injectDependencies(ctx context.Context, h any) error {
+	fields := dependencyFields(reflect.TypeOf(h))
+	if len(fields) == 0 {
+		return nil
+	}
+
+	handlerValue := reflect.ValueOf(h).Elem()
+	for _, field := range fields {
+		output := ctx.Value(middlewareOutputKey{field.t})
+		if output == nil {
+			return NewError(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError),
+				fmt.Sprintf("dependency %s of field %s is not provided by middlewares", field.t, field.name))
+		}
+		fieldValue, err := getOrInitFieldByIndex(handlerValue, field.index)
+		if err != nil {
+			return fmt.Errorf("prepare dependency field failed: %w", err)
+		}
+		fieldValue.Addr().Interface().(dependency).set(output)
+	}
+	return nil
+}
+
+// ContextWithDependency 将类型为T的值按照NewMiddleware注册的中间件的方式存储到上下文中
+// 单元测试中可以用来注入伪造的中间件输出，再通过InjectDependencies填充Dep[T]字段
+func ContextWithDependency[T any](ctx context.Context, value T) context.Context {
+	return context.WithValue(ctx, middlewareOutputKey{reflect.TypeOf((*T)(nil)).Elem()}, value)
+}
+
+// InjectDependencies 从上下文中获取中间件的输出并填充API的Dep字段，与参数绑定完成后的处理一致
+// 参数h必须是结构体指针，上下文中缺少依赖时返回错误
+func InjectDependencies(ctx context.Context, h any) error {
+	if v := reflect.ValueOf(h); v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%T: expected non-nil struct pointer", h)
+	}
+	return injectDependencies(ctx, h)
+}
+
+// validateDependencies 检查处理器的依赖是否都由中间件链提供
+// 参数outputs为中间件链中NewMiddleware注册的输出类型
+func validateDependencies(h RouterHandler, outputs map[reflect.Type]string) error {
+	for _, field := range dependencyFields(reflect.TypeOf(handlerValue(h))) {
+		if _, ok := outputs[field.t]; !ok {
+			return fmt.Errorf("field %s depends on %s, which is not provided by any middleware registered with NewMiddleware", field.name, field.t)
+		}
+	}
+	return nil
+}
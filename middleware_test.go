@@ -0,0 +1,215 @@
+package easygin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTypedMiddleware(t *testing.T) {
+	request := func(srv *Server, path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Token", token)
+		w := httptest.NewRecorder()
+		srv.engine.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Dep", func(t *testing.T) {
+		srv := NewServer("test", ":0", false)
+		root := NewRouterGroup("/", NewMiddleware[*TestDepUser](&TestDepAuth{}))
+		root.RegisterAPI(&TestDepAPI{})
+		// 子路由组继承父路由组的中间件输出
+		sub := NewRouterGroup("/sub", NewMiddleware[TestDepTenant](&TestDepTenantMiddleware{}))
+		sub.RegisterAPI(&TestDepNestedAPI{})
+		root.RegisterGroup(sub)
+		srv.setup(root)
+
+		if w := request(srv, "/dep", "admin"); w.Code != http.StatusOK || w.Body.String() != "admin" {
+			t.Fatalf("expected dependency from middleware, got %d %s", w.Code, w.Body.String())
+		}
+		if w := request(srv, "/dep", ""); w.Code != http.StatusUnauthorized {
+			t.Fatalf("expected middleware error, got %d %s", w.Code, w.Body.String())
+		}
+		if w := request(srv, "/sub/dep", "admin"); w.Code != http.StatusOK || w.Body.String() != "admin@tenant-admin" {
+			t.Fatalf("expected nested dependencies, got %d %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("MissingDependency", func(t *testing.T) {
+		root := NewRouterGroup("/")
+		root.RegisterAPI(&TestDepAPI{})
+		assertSetupPanics(t, root, "*easygin.TestDepUser")
+	})
+
+	t.Run("MiddlewareDependency", func(t *testing.T) {
+		root := NewRouterGroup("/", NewMiddleware[TestDepTenant](&TestDepTenantMiddleware{}))
+		root.RegisterAPI(&TestDriftAPI{})
+		assertSetupPanics(t, root, "middleware easygin.TestDepTenantMiddleware")
+	})
+
+	t.Run("DuplicateOutput", func(t *testing.T) {
+		root := NewRouterGroup("/", NewMiddleware[*TestDepUser](&TestDepAuth{}), NewMiddleware[*TestDepUser](&TestDepAuth{}))
+		root.RegisterAPI(&TestDepAPI{})
+		assertSetupPanics(t, root, "already provided by easygin.TestDepAuth")
+	})
+
+	t.Run("NilInterfaceOutput", func(t *testing.T) {
+		srv := NewServer("test", ":0", false)
+		root := NewRouterGroup("/", NewMiddleware[TestDepPrincipal](&TestDepNilPrincipal{}))
+		root.RegisterAPI(&TestDepPrincipalAPI{})
+		srv.setup(root)
+
+		w := request(srv, "/principal", "")
+		if w.Code != http.StatusInternalServerError || !strings.Contains(w.Body.String(), "TestDepNilPrincipal returned nil easygin.TestDepPrincipal") {
+			t.Fatalf("expected error naming the middleware, got %d %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("NilPointerOutput", func(t *testing.T) {
+		srv := NewServer("test", ":0", false)
+		root := NewRouterGroup("/", NewMiddleware[*TestDepUser](&TestDepNilUser{}))
+		root.RegisterAPI(&TestDepAPI{})
+		srv.setup(root)
+
+		w := request(srv, "/dep", "")
+		if w.Code != http.StatusInternalServerError || !strings.Contains(w.Body.String(), "TestDepNilUser returned nil *easygin.TestDepUser") {
+			t.Fatalf("expected error naming the middleware, got %d %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("EmbeddedMiddlewareParameters", func(t *testing.T) {
+		// 类型化中间件嵌入普通中间件时，嵌入结构体中的参数同样绑定并生成文档
+		srv := NewServer("test", ":0", false)
+		root := NewRouterGroup("/", NewMiddleware[*TestDepUser](&TestDepEmbeddedAuth{}))
+		root.RegisterAPI(&TestDepAPI{})
+		srv.setup(root)
+		if w := request(srv, "/dep", "embedded"); w.Code != http.StatusOK || w.Body.String() != "embedded" {
+			t.Fatalf("expected embedded parameter to be bound, got %d %s", w.Code, w.Body.String())
+		}
+
+		doc, err := BuildOpenAPI(root)
+		if err != nil {
+			t.Fatalf("BuildOpenAPI returned error: %v", err)
+		}
+		params := doc.Paths.Value("/dep").Get.Parameters
+		if len(params) != 1 || params[0].Value.Name != "Token" || params[0].Value.In != "header" {
+			t.Fatalf("expected embedded middleware parameter in document, got %+v", params)
+		}
+	})
+
+	t.Run("NewDep", func(t *testing.T) {
+		api := &TestDepAPI{User: NewDep(&TestDepUser{Name: "mock"})}
+		if output, err := api.Output(context.Background()); err != nil || output != "mock" {
+			t.Fatalf("expected mocked dependency, got %v %v", output, err)
+		}
+	})
+}
+
+func assertSetupPanics(t *testing.T, root *RouterGroup, contains string) {
+	t.Helper()
+	defer func() {
+		r := recover()
+		if msg, _ := r.(string); !strings.Contains(msg, contains) {
+			t.Fatalf("expected panic containing %q, got %v", contains, r)
+		}
+	}()
+	NewServer("test", ":0", false).setup(root)
+}
+
+type TestDepUser struct {
+	Name string
+}
+
+// TestDepAuth 输出类型为*TestDepUser的中间件
+type TestDepAuth struct {
+	Token string `in:"header" name:"Token,omitempty"`
+}
+
+func (m *TestDepAuth) Output(ctx context.Context) (*TestDepUser, error) {
+	if m.Token == "" {
+		return nil, NewError(http.StatusUnauthorized, "unauthorized", "token is empty")
+	}
+	return &TestDepUser{Name: m.Token}, nil
+}
+
+type TestDepTenant string
+
+// TestDepTenantMiddleware 依赖TestDepAuth输出的中间件
+type TestDepTenantMiddleware struct {
+	User Dep[*TestDepUser]
+}
+
+func (m *TestDepTenantMiddleware) Output(ctx context.Context) (TestDepTenant, error) {
+	return TestDepTenant("tenant-" + m.User.Get().Name), nil
+}
+
+type TestDepAPI struct {
+	MethodGet
+	User Dep[*TestDepUser]
+}
+
+func (TestDepAPI) Path() string {
+	return "/dep"
+}
+
+func (req *TestDepAPI) Output(ctx context.Context) (any, error) {
+	return req.User.Get().Name, nil
+}
+
+// TestDepNestedAPI 通过嵌入结构体声明依赖
+type TestDepNestedAPI struct {
+	MethodGet
+	TestDepAPI
+	Tenant Dep[TestDepTenant]
+}
+
+func (TestDepNestedAPI) Path() string {
+	return "/dep"
+}
+
+func (req *TestDepNestedAPI) Output(ctx context.Context) (any, error) {
+	return req.User.Get().Name + "@" + string(req.Tenant.Get()), nil
+}
+
+// TestDepEmbeddedAuth 嵌入TestDepAuth的类型化中间件
+type TestDepEmbeddedAuth struct {
+	TestDepAuth
+}
+
+func (m *TestDepEmbeddedAuth) Output(ctx context.Context) (*TestDepUser, error) {
+	return m.TestDepAuth.Output(ctx)
+}
+
+// TestDepNilUser 没有错误时返回nil指针
+type TestDepNilUser struct{}
+
+func (m *TestDepNilUser) Output(ctx context.Context) (*TestDepUser, error) {
+	return nil, nil
+}
+
+type TestDepPrincipal interface {
+	PrincipalName() string
+}
+
+// TestDepNilPrincipal 输出类型为接口，没有错误时返回nil
+type TestDepNilPrincipal struct{}
+
+func (m *TestDepNilPrincipal) Output(ctx context.Context) (TestDepPrincipal, error) {
+	return nil, nil
+}
+
+type TestDepPrincipalAPI struct {
+	MethodGet
+	Principal Dep[TestDepPrincipal]
+}
+
+func (TestDepPrincipalAPI) Path() string {
+	return "/principal"
+}
+
+func (req *TestDepPrincipalAPI) Output(ctx context.Context) (any, error) {
+	return req.Principal.Get().PrincipalName(), nil
+}
@@ -2,19 +2,25 @@ package easygin
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"mime/multipart"
+	"net/http"
 	"os"
 	"path"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unicode"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gin-gonic/gin"
+	"github.com/oasdiff/yaml"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"github.com/swaggo/swag"
@@ -25,19 +31,56 @@ func Ptr[T any](v T) *T {
 	return &v
 }
 
-// 添加一个全局变量，用于记录已处理的类型
-var processedTypes map[string]bool
-
+// GenerateOpenAPI 根据路由组生成OpenAPI文档并保存为openapi.json，错误响应使用默认的JSONErrorRenderer格式
 func GenerateOpenAPI(groups ...*RouterGroup) error {
+	return generateOpenAPI(JSONErrorRenderer{}, OpenAPIOptions{}, groups...)
+}
+
+// BuildOpenAPI 根据路由组生成OpenAPI文档，错误响应使用默认的JSONErrorRenderer格式
+// 需要保存为文件时调用SaveOpenAPI
+func BuildOpenAPI(groups ...*RouterGroup) (*openapi3.T, error) {
+	return buildOpenAPIDoc(JSONErrorRenderer{}, OpenAPIOptions{}, groups...)
+}
+
+// BuildOpenAPIWithOptions 根据路由组生成OpenAPI文档，文档的标题、版本、服务地址和安全认证方式等元数据由opts决定
+func BuildOpenAPIWithOptions(opts OpenAPIOptions, groups ...*RouterGroup) (*openapi3.T, error) {
+	return buildOpenAPIDoc(JSONErrorRenderer{}, opts, groups...)
+}
+
+// SaveOpenAPI 将OpenAPI文档保存为JSON文件
+func SaveOpenAPI(doc *openapi3.T, file string) error {
+	docBytes, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, docBytes, 0o644)
+}
+
+// generateOpenAPI 生成OpenAPI文档并保存为openapi.json，错误响应的格式由renderer决定
+func generateOpenAPI(renderer ErrorRenderer, opts OpenAPIOptions, groups ...*RouterGroup) error {
 	fmt.Println("Generating file for OpenAPI specification...")
 
-	// 初始化正在处理的类型映射
-	processedTypes = make(map[string]bool)
+	doc, err := buildOpenAPIDoc(renderer, opts, groups...)
+	if err != nil {
+		return err
+	}
 
+	// 将文档保存为 JSON 文件
+	if err = SaveOpenAPI(doc, "openapi.json"); err != nil {
+		return err
+	}
+
+	fmt.Println("Successfully generated file openapi.json.")
+
+	return nil
+}
+
+// buildOpenAPIDoc 遍历路由组生成OpenAPI文档，错误响应的格式由renderer决定，文档元数据由opts决定
+func buildOpenAPIDoc(renderer ErrorRenderer, opts OpenAPIOptions, groups ...*RouterGroup) (*openapi3.T, error) {
 	// 创建 OpenAPI 规范文档
 	paths := openapi3.Paths{}
 	doc := &openapi3.T{
-		OpenAPI: "3.0.3",
+		OpenAPI: OpenAPIVersion30,
 		Info: &openapi3.Info{
 			Title: "RESTful API",
 		},
@@ -46,27 +89,26 @@ func GenerateOpenAPI(groups ...*RouterGroup) error {
 			Schemas: make(map[string]*openapi3.SchemaRef),
 		},
 	}
+	if err := opts.apply(doc); err != nil {
+		return nil, err
+	}
 
 	// 遍历所有路由组
 	for _, group := range groups {
-		if err := generateGroupPaths(doc, group, ""); err != nil {
-			return err
+		if err := generateGroupPaths(doc, renderer, group, "", nil); err != nil {
+			return nil, err
 		}
 	}
 
-	// 将文档保存为 JSON 文件
-	docBytes, err := json.MarshalIndent(doc, "", "  ")
-	if err != nil {
-		return err
+	if err := validateSecurity(doc); err != nil {
+		return nil, err
 	}
 
-	if err = os.WriteFile("openapi.json", docBytes, 0o644); err != nil {
-		return err
+	if isOpenAPI31(doc) {
+		convertToOpenAPI31(doc)
 	}
 
-	fmt.Println("Successfully generated file openapi.json.")
-
-	return nil
+	return doc, nil
 }
 
 func snakeToPascalCase(s string) string {
@@ -93,6 +135,16 @@ func generateSchema(doc *openapi3.T, t reflect.Type, isMultipart bool) *openapi3
 		t = t.Elem()
 	}
 
+	// 类型自定义的schema优先
+	if schema := providedSchema(t); schema != nil {
+		return schema
+	}
+
+	// 实现了EnumValues接口的枚举类型
+	if schema := enumSchema(t); schema != nil {
+		return schema
+	}
+
 	// 特殊处理multipart.FileHeader类型及其衍生类型
 	if isFileHeaderTypeOrAlias(t) {
 		schema := openapi3.NewStringSchema()
@@ -107,33 +159,27 @@ func generateSchema(doc *openapi3.T, t reflect.Type, isMultipart bool) *openapi3
 		return schema
 	}
 
+	// 特殊处理sql.Null*、[]byte、time.Duration等序列化格式特殊的类型
+	if schema := wellKnownSchema(doc, t, isMultipart); schema != nil {
+		return schema
+	}
+
 	// 检查是否已经在components/schemas中定义过该类型
 	if t.Kind() == reflect.Struct && t.PkgPath() != "" {
 		// 将包路径和类型名转换为有效的组件名
 		schemaName := snakeToPascalCase(t.PkgPath() + "." + t.Name())
 
-		// 检查是否已经在处理这个类型，避免无限递归
-		if processedTypes[schemaName] {
-			// 如果正在处理或已处理过，直接返回引用
-			return &openapi3.Schema{
-				// 不使用 AllOf，而是直接使用 $ref 字段
-				Extensions: map[string]any{
-					"$ref": "#/components/schemas/" + schemaName,
-				},
-			}
-		}
-
+		// components/schemas中已有该类型（包括正在处理的类型）时直接返回引用，避免无限递归
 		if _, exists := doc.Components.Schemas[schemaName]; !exists {
-			// 标记该类型正在处理中
-			processedTypes[schemaName] = true
+			// 先占位标记该类型正在处理中，状态保存在本次生成的文档中，并发生成文档时互不影响
+			ref := &openapi3.SchemaRef{}
+			doc.Components.Schemas[schemaName] = ref
 
 			// 将类型定义添加到components/schemas
-			schemaValue := generateSchemaValue(doc, t, isMultipart)
-
-			if schemaValue != nil {
-				doc.Components.Schemas[schemaName] = &openapi3.SchemaRef{
-					Value: schemaValue,
-				}
+			if schemaValue := generateSchemaValue(doc, t, isMultipart); schemaValue != nil {
+				ref.Value = schemaValue
+			} else {
+				delete(doc.Components.Schemas, schemaName)
 			}
 		}
 		// 返回对该类型的引用
@@ -163,7 +209,9 @@ func generateOperationID(apiType reflect.Type) string {
 	return strings.ToLower(structName[:1]) + structName[1:]
 }
 
-func generateGroupPaths(doc *openapi3.T, group *RouterGroup, parentPath string, parentMiddlewareParams ...*openapi3.ParameterRef) error {
+// generateGroupPaths 遍历路由组生成路径和操作
+// parentSecurity为父级路由组中间件声明的安全要求，parentMiddlewareParams为父级路由组中间件的参数
+func generateGroupPaths(doc *openapi3.T, renderer ErrorRenderer, group *RouterGroup, parentPath string, parentSecurity openapi3.SecurityRequirements, parentMiddlewareParams ...*openapi3.ParameterRef) error {
 	// 处理当前组的路径前缀
 	basePath := joinURLPath(parentPath, group.path)
 
@@ -174,9 +222,17 @@ func generateGroupPaths(doc *openapi3.T, group *RouterGroup, parentPath string,
 	middlewareParams := make([]*openapi3.ParameterRef, len(parentMiddlewareParams))
 	copy(middlewareParams, parentMiddlewareParams)
 
+	// 合并当前组中间件声明的安全要求
+	security := parentSecurity
+	for _, middleware := range group.middlewares {
+		if declarer, ok := handlerValue(middleware).(OpenAPISecurity); ok {
+			security = mergeSecurity(security, declarer.OpenAPISecurity())
+		}
+	}
+
 	// 处理当前组的中间件参数
 	for _, middleware := range group.middlewares {
-		middlewareType := reflect.TypeOf(middleware)
+		middlewareType := reflect.TypeOf(handlerValue(middleware))
 		if middlewareType.Kind() == reflect.Ptr {
 			middlewareType = middlewareType.Elem()
 		}
@@ -184,35 +240,7 @@ func generateGroupPaths(doc *openapi3.T, group *RouterGroup, parentPath string,
 			continue
 		}
 
-		// 遍历中间件的字段
-		for i := 0; i < middlewareType.NumField(); i++ {
-			field := middlewareType.Field(i)
-			inTag := field.Tag.Get("in")
-			if inTag == "body" {
-				panic("parameters in middleware cannot use `in:\"body\"` tag")
-			}
-			if inTag == "path" || inTag == "query" || inTag == "header" {
-				name := field.Tag.Get("name")
-				nameParts := strings.Split(name, ",")
-				paramName := nameParts[0]
-				isRequired := true
-				if len(nameParts) > 1 && nameParts[1] == "omitempty" {
-					isRequired = false
-				}
-
-				// 获取desc标签的值
-				desc := field.Tag.Get("desc")
-
-				param := &openapi3.Parameter{
-					Name:        paramName,
-					In:          inTag,
-					Schema:      &openapi3.SchemaRef{Value: generateSchema(doc, field.Type, false)},
-					Required:    isRequired,
-					Description: desc, // 设置描述信息
-				}
-				middlewareParams = append(middlewareParams, &openapi3.ParameterRef{Value: param})
-			}
-		}
+		middlewareParams = append(middlewareParams, middlewareParameters(doc, middlewareType)...)
 	}
 
 	// 遍历组中的所有 API
@@ -256,20 +284,7 @@ func generateGroupPaths(doc *openapi3.T, group *RouterGroup, parentPath string,
 
 		// 创建操作对象
 		responses := openapi3.NewResponses(func(r *openapi3.Responses) {
-			defaultRef := &openapi3.ResponseRef{
-				Value: &openapi3.Response{
-					Description: Ptr("Default response"),
-					Content: openapi3.Content{
-						"application/json": &openapi3.MediaType{
-							Schema: &openapi3.SchemaRef{
-								Value: generateSchema(doc, reflect.TypeOf(&Error{}), false),
-							},
-						},
-					},
-				},
-			}
-			defaultRef.Value.Description = Ptr("Default response with error")
-			r.Set("default", defaultRef)
+			r.Set("default", generateErrorResponse(doc, renderer, &Error{}, "Default response with error"))
 		})
 
 		// 检查是否实现了RouterResponse接口
@@ -283,13 +298,7 @@ func generateGroupPaths(doc *openapi3.T, group *RouterGroup, parentPath string,
 				}
 				// 只有当resp不为nil时才添加Content字段
 				if resp != nil {
-					responseRef.Value.Content = openapi3.Content{
-						"application/json": &openapi3.MediaType{
-							Schema: &openapi3.SchemaRef{
-								Value: generateSchema(doc, reflect.TypeOf(resp), false),
-							},
-						},
-					}
+					responseRef.Value.Content = responseContent(doc, api, resp)
 				}
 				responses.Set(strconv.Itoa(code), responseRef)
 			}
@@ -311,47 +320,56 @@ func generateGroupPaths(doc *openapi3.T, group *RouterGroup, parentPath string,
 		// 生成并设置 operationId
 		op.OperationID = generateOperationID(apiType)
 
-		// 获取 HTTP 方法和摘要
-		for i := 0; i < apiType.NumField(); i++ {
-			field := apiType.Field(i)
-			if strings.HasPrefix(field.Type.Name(), "Method") {
-				op.Summary = field.Tag.Get("summary")
-				method := strings.ToLower(strings.TrimPrefix(field.Type.Name(), "Method"))
-
-				// 确保路径存在
-				pathItem := doc.Paths.Value(apiPath)
-				if pathItem == nil {
-					pathItem = &openapi3.PathItem{}
-					doc.Paths.Set(apiPath, pathItem)
-				}
+		// 获取摘要、详细说明和废弃标记
+		op.Summary = getHandlerDescription(api)
+		op.Description = getHandlerDetail(api)
+		op.Deprecated = isHandlerDeprecated(api)
 
-				// 设置操作
-				switch method {
-				case "get":
-					pathItem.Get = op
-				case "post":
-					pathItem.Post = op
-				case "put":
-					pathItem.Put = op
-				case "delete":
-					pathItem.Delete = op
-				}
+		// 添加响应示例
+		if err := applyResponseExamples(op, api); err != nil {
+			return err
+		}
 
-				// 添加中间件参数到操作中
-				if len(middlewareParams) > 0 {
-					op.Parameters = append(op.Parameters, middlewareParams...)
-				}
-				break
+		// WebSocket以GET请求升级连接，通过扩展字段标记
+		if _, ok := api.(WebSocketHandler); ok {
+			op.Extensions = map[string]any{"x-websocket": true}
+			if op.Responses.Value("101") == nil {
+				op.Responses.Set("101", &openapi3.ResponseRef{Value: &openapi3.Response{
+					Description: Ptr("Switching Protocols"),
+				}})
+			}
+			// 未声明响应时去掉默认的200响应
+			if _, ok := api.(RouterResponse); !ok {
+				op.Responses.Delete("200")
 			}
 		}
 
+		// 中间件声明了安全要求时标记操作
+		if len(security) > 0 {
+			op.Security = &security
+		}
+
+		// 添加中间件参数到操作中
+		if len(middlewareParams) > 0 {
+			op.Parameters = append(op.Parameters, middlewareParams...)
+		}
+
 		// 处理请求参数
 		processStructFields(doc, apiType, op, nil)
+
+		// 存在请求参数且未声明400响应时，添加参数校验错误响应
+		if (len(op.Parameters) > 0 || op.RequestBody != nil) && op.Responses.Value("400") == nil {
+			op.Responses.Set("400", generateErrorResponse(doc, renderer, &ValidationError{}, "Invalid parameters"))
+		}
+
+		if err := addOperations(doc, apiPath, api, op); err != nil {
+			return err
+		}
 	}
 
 	// 递归处理子组，传递当前组的中间件参数
 	for _, subGroup := range group.children {
-		if err := generateGroupPaths(doc, subGroup, basePath, middlewareParams...); err != nil {
+		if err := generateGroupPaths(doc, renderer, subGroup, basePath, security, middlewareParams...); err != nil {
 			return err
 		}
 	}
@@ -359,6 +377,140 @@ func generateGroupPaths(doc *openapi3.T, group *RouterGroup, parentPath string,
 	return nil
 }
 
+// applyResponseExamples 将API的Examples添加到对应状态码响应的所有媒体类型
+func applyResponseExamples(op *openapi3.Operation, api RouterAPI) error {
+	withExamples, ok := api.(RouterExamples)
+	if !ok {
+		return nil
+	}
+	for code, example := range withExamples.Examples() {
+		resp := op.Responses.Value(strconv.Itoa(code))
+		if resp == nil || resp.Value == nil || len(resp.Value.Content) == 0 {
+			return fmt.Errorf("example for status %d of %s has no declared response content", code, op.OperationID)
+		}
+		for _, media := range resp.Value.Content {
+			media.Example = example
+		}
+	}
+	return nil
+}
+
+// anyMethods MethodAny在OpenAPI文档中展开的HTTP方法，与gin的Any注册的方法一致
+var anyMethods = []string{
+	http.MethodGet,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodHead,
+	http.MethodOptions,
+	http.MethodDelete,
+	http.MethodConnect,
+	http.MethodTrace,
+}
+
+// middlewareParameters 生成中间件字段声明的参数，与参数绑定一致，嵌入结构体中的字段同样处理
+func middlewareParameters(doc *openapi3.T, t reflect.Type) []*openapi3.ParameterRef {
+	params := make([]*openapi3.ParameterRef, 0)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		inTag := field.Tag.Get("in")
+
+		// 处理嵌入字段（支持指针形式）
+		if field.Anonymous && inTag == "" {
+			embeddedType := field.Type
+			if embeddedType.Kind() == reflect.Ptr {
+				embeddedType = embeddedType.Elem()
+			}
+			if embeddedType.Kind() == reflect.Struct {
+				params = append(params, middlewareParameters(doc, embeddedType)...)
+			}
+			continue
+		}
+
+		if inTag == "body" {
+			panic("parameters in middleware cannot use `in:\"body\"` tag")
+		}
+		if inTag == "path" || inTag == "query" || inTag == "header" || inTag == "cookie" {
+			name := field.Tag.Get("name")
+			nameParts := strings.Split(name, ",")
+			paramName := nameParts[0]
+			isRequired := true
+			if len(nameParts) > 1 && nameParts[1] == "omitempty" {
+				isRequired = false
+			}
+
+			// 获取desc标签的值
+			desc := field.Tag.Get("desc")
+
+			schema := generateSchema(doc, field.Type, false)
+			applyValidateRules(schema, field.Tag.Get("validate"))
+			applyEnumTag(schema, field)
+
+			param := &openapi3.Parameter{
+				Name:        paramName,
+				In:          inTag,
+				Schema:      &openapi3.SchemaRef{Value: schema},
+				Required:    isRequired,
+				Description: desc, // 设置描述信息
+				Example:     fieldExample(schema, field),
+			}
+			params = append(params, &openapi3.ParameterRef{Value: param})
+		}
+	}
+	return params
+}
+
+// addOperations 按照API的Method()将操作添加到路径中
+// MethodAny展开为每个HTTP方法，operationId添加方法名后缀，同一路径和方法已存在操作时返回错误
+func addOperations(doc *openapi3.T, apiPath string, api RouterAPI, op *openapi3.Operation) error {
+	method := strings.ToUpper(api.Method())
+	methods := []string{method}
+	if method == "ANY" {
+		methods = anyMethods
+	} else if !slices.Contains(anyMethods, method) {
+		return fmt.Errorf("unsupported HTTP method %q: %s", api.Method(), reflect.TypeOf(api).String())
+	}
+
+	pathItem := doc.Paths.Value(apiPath)
+	if pathItem == nil {
+		pathItem = &openapi3.PathItem{}
+		doc.Paths.Set(apiPath, pathItem)
+	}
+
+	for _, m := range methods {
+		if existing := pathItem.GetOperation(m); existing != nil {
+			return fmt.Errorf("duplicate operation %s %s: %s conflicts with operation %s", m, apiPath, reflect.TypeOf(api).String(), existing.OperationID)
+		}
+	}
+
+	if len(methods) == 1 {
+		pathItem.SetOperation(method, op)
+		return nil
+	}
+	for _, m := range methods {
+		methodOp := *op
+		methodOp.OperationID = op.OperationID + snakeToPascalCase(strings.ToLower(m))
+		pathItem.SetOperation(m, &methodOp)
+	}
+	return nil
+}
+
+// generateErrorResponse 按照ErrorRenderer的格式生成错误响应
+func generateErrorResponse(doc *openapi3.T, renderer ErrorRenderer, err error, description string) *openapi3.ResponseRef {
+	return &openapi3.ResponseRef{
+		Value: &openapi3.Response{
+			Description: Ptr(description),
+			Content: openapi3.Content{
+				renderer.ContentType(): &openapi3.MediaType{
+					Schema: &openapi3.SchemaRef{
+						Value: generateSchema(doc, reflect.TypeOf(renderer.Model(err)), false),
+					},
+				},
+			},
+		},
+	}
+}
+
 // 处理结构体字段，包括嵌入字段
 func processStructFields(doc *openapi3.T, t reflect.Type, op *openapi3.Operation, processedTypes map[reflect.Type]bool) {
 	// 初始化已处理类型的映射，防止循环引用
@@ -384,22 +536,9 @@ func processStructFields(doc *openapi3.T, t reflect.Type, op *openapi3.Operation
 			if inTag != "" {
 				// 处理in:"body"标签
 				if inTag == "body" {
-					mimeType := field.Tag.Get("mime")
-					contentType := "application/json"
-					isMultipart := false
-					if mimeType == "multipart" {
-						contentType = "multipart/form-data"
-						isMultipart = true
-					}
 					op.RequestBody = &openapi3.RequestBodyRef{
 						Value: &openapi3.RequestBody{
-							Content: openapi3.Content{
-								contentType: &openapi3.MediaType{
-									Schema: &openapi3.SchemaRef{
-										Value: generateSchema(doc, field.Type, isMultipart),
-									},
-								},
-							},
+							Content: requestBodyContent(doc, field),
 						},
 					}
 				} else {
@@ -412,12 +551,16 @@ func processStructFields(doc *openapi3.T, t reflect.Type, op *openapi3.Operation
 						isRequired = false
 					}
 					desc := field.Tag.Get("desc")
+					schema := generateSchema(doc, field.Type, false)
+					applyValidateRules(schema, field.Tag.Get("validate"))
+					applyEnumTag(schema, field)
 					param := &openapi3.Parameter{
 						Name:        paramName,
 						In:          inTag,
-						Schema:      &openapi3.SchemaRef{Value: generateSchema(doc, field.Type, false)},
+						Schema:      &openapi3.SchemaRef{Value: schema},
 						Required:    isRequired,
 						Description: desc,
+						Example:     fieldExample(schema, field),
 					}
 					op.Parameters = append(op.Parameters, &openapi3.ParameterRef{Value: param})
 				}
@@ -447,33 +590,24 @@ func processStructFields(doc *openapi3.T, t reflect.Type, op *openapi3.Operation
 
 			// 处理 body 参数
 			if inTag == "body" {
-				mimeType := field.Tag.Get("mime")
-				contentType := "application/json"
-				isMultipart := false
-				if mimeType == "multipart" {
-					contentType = "multipart/form-data"
-					isMultipart = true
-				}
 				op.RequestBody = &openapi3.RequestBodyRef{
 					Value: &openapi3.RequestBody{
-						Content: openapi3.Content{
-							contentType: &openapi3.MediaType{
-								Schema: &openapi3.SchemaRef{
-									Value: generateSchema(doc, field.Type, isMultipart),
-								},
-							},
-						},
+						Content: requestBodyContent(doc, field),
 					},
 				}
 			} else {
 				// 处理其他类型参数
 				desc := field.Tag.Get("desc")
+				schema := generateSchema(doc, field.Type, false)
+				applyValidateRules(schema, field.Tag.Get("validate"))
+				applyEnumTag(schema, field)
 				param := &openapi3.Parameter{
 					Name:        paramName,
 					In:          inTag,
-					Schema:      &openapi3.SchemaRef{Value: generateSchema(doc, field.Type, false)},
+					Schema:      &openapi3.SchemaRef{Value: schema},
 					Required:    isRequired,
 					Description: desc,
+					Example:     fieldExample(schema, field),
 				}
 				op.Parameters = append(op.Parameters, &openapi3.ParameterRef{Value: param})
 			}
@@ -481,6 +615,54 @@ func processStructFields(doc *openapi3.T, t reflect.Type, op *openapi3.Operation
 	}
 }
 
+// responseContent 生成响应体的内容
+// 事件流使用text/event-stream，schema为单个事件数据的模型，其他响应体按照Method字段上mime标签中声明的Codec列出所有媒体类型
+func responseContent(doc *openapi3.T, api RouterHandler, resp any) openapi3.Content {
+	var stream *EventStream
+	switch v := resp.(type) {
+	case EventStream:
+		stream = &v
+	case *EventStream:
+		stream = v
+	}
+	if stream != nil {
+		schema := openapi3.NewStringSchema()
+		if stream.Model != nil {
+			schema = generateSchema(doc, reflect.TypeOf(stream.Model), false)
+		}
+		return openapi3.NewContentWithSchema(schema, []string{ContentTypeEventStream})
+	}
+
+	return codecContent(methodCodecTag(api), reflect.TypeOf(resp), generateSchema(doc, reflect.TypeOf(resp), false))
+}
+
+// requestBodyContent 根据mime标签生成请求体的内容
+// 表单字段使用name标签而不是json标签，其他请求体按照mime标签中声明的Codec列出所有媒体类型
+func requestBodyContent(doc *openapi3.T, field reflect.StructField) openapi3.Content {
+	switch mime := field.Tag.Get("mime"); mime {
+	case "multipart":
+		return openapi3.NewContentWithSchema(generateSchema(doc, field.Type, true), []string{"multipart/form-data"})
+	case "urlencoded":
+		return openapi3.NewContentWithSchema(generateSchema(doc, field.Type, true), []string{"application/x-www-form-urlencoded"})
+	default:
+		// 请求体解码到字段的指针
+		bodyType := field.Type
+		if bodyType.Kind() != reflect.Ptr {
+			bodyType = reflect.PointerTo(bodyType)
+		}
+		return codecContent(mime, bodyType, generateSchema(doc, field.Type, false))
+	}
+}
+
+// codecContent 为mime标签中声明的Codec中支持类型t的Codec生成内容，未声明时只包含JSON
+func codecContent(mime string, t reflect.Type, schema *openapi3.Schema) openapi3.Content {
+	candidates, err := supportedCodecs(parseCodecTag(mime), t)
+	if err != nil {
+		panic(err)
+	}
+	return openapi3.NewContentWithSchema(schema, codecMediaTypes(candidates))
+}
+
 // 将:param格式的路径参数转换为{param}格式
 func convertPathParams(path string) string {
 	parts := strings.Split(path, "/")
@@ -514,9 +696,125 @@ func isFileHeaderTypeOrAlias(t reflect.Type) bool {
 	return t.ConvertibleTo(timeType) && t.Kind() == timeType.Kind()
 }
 
-var doc *openapi3.T
+// applyValidateRules 将validate标签规则映射到OpenAPI schema
+// 数值类型映射为minimum/maximum，字符串映射为minLength/maxLength，数组映射为minItems/maxItems
+// pattern、format和enum作用于字符串或数组元素
+func applyValidateRules(schema *openapi3.Schema, tag string) {
+	if schema == nil || tag == "" {
+		return
+	}
+
+	rules, err := parseValidateRules(tag)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	// 引用类型的schema不添加校验规则
+	if _, isRef := schema.Extensions["$ref"]; isRef {
+		return
+	}
+
+	target := schema
+	switch {
+	case schema.Type.Is(openapi3.TypeArray):
+		if rules.length != nil {
+			schema.MinItems = uint64(*rules.length)
+			schema.MaxItems = Ptr(uint64(*rules.length))
+		}
+		if rules.min != nil {
+			schema.MinItems = uint64(*rules.min)
+		}
+		if rules.max != nil {
+			schema.MaxItems = Ptr(uint64(*rules.max))
+		}
+		// 元素规则作用于items
+		if schema.Items == nil || schema.Items.Value == nil {
+			return
+		}
+		target = schema.Items.Value
+	case schema.Type.Is(openapi3.TypeString):
+		if rules.length != nil {
+			schema.MinLength = uint64(*rules.length)
+			schema.MaxLength = Ptr(uint64(*rules.length))
+		}
+		if rules.min != nil {
+			schema.MinLength = uint64(*rules.min)
+		}
+		if rules.max != nil {
+			schema.MaxLength = Ptr(uint64(*rules.max))
+		}
+	case schema.Type.Is(openapi3.TypeInteger), schema.Type.Is(openapi3.TypeNumber):
+		schema.Min = rules.min
+		schema.Max = rules.max
+	case schema.Type.Is(openapi3.TypeObject):
+		if rules.min != nil {
+			schema.MinProps = uint64(*rules.min)
+		}
+		if rules.max != nil {
+			schema.MaxProps = Ptr(uint64(*rules.max))
+		}
+		return
+	}
+
+	if rules.pattern != nil {
+		target.Pattern = rules.pattern.String()
+	}
+	if rules.format != "" {
+		target.Format = rules.format
+	}
+	if len(rules.enum) > 0 {
+		target.Enum = make([]any, 0, len(rules.enum))
+		for _, e := range rules.enum {
+			target.Enum = append(target.Enum, enumSchemaValue(target, e))
+		}
+	}
+}
+
+// fieldExample 根据schema类型转换字段example标签的值，未设置时返回nil
+// 数组、对象和引用类型的示例按照JSON解析
+func fieldExample(schema *openapi3.Schema, field reflect.StructField) any {
+	value, ok := field.Tag.Lookup("example")
+	if !ok || schema == nil {
+		return nil
+	}
+	// nullable的引用类型通过allOf包装
+	if len(schema.AllOf) == 1 && schema.AllOf[0].Value != nil {
+		schema = schema.AllOf[0].Value
+	}
+	if _, isRef := schema.Extensions["$ref"]; isRef || schema.Type.Is(openapi3.TypeArray) || schema.Type.Is(openapi3.TypeObject) {
+		var example any
+		if err := json.Unmarshal([]byte(value), &example); err == nil {
+			return example
+		}
+		return value
+	}
+	return enumSchemaValue(schema, value)
+}
+
+// enumSchemaValue 根据schema类型转换枚举值，转换失败时保留字符串
+func enumSchemaValue(schema *openapi3.Schema, value string) any {
+	switch {
+	case schema.Type.Is(openapi3.TypeInteger):
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	case schema.Type.Is(openapi3.TypeNumber):
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return n
+		}
+	case schema.Type.Is(openapi3.TypeBoolean):
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
 
 func generateSchemaValue(doc *openapi3.T, t reflect.Type, isMultipart bool) *openapi3.Schema {
+	if schema := providedSchema(t); schema != nil {
+		return schema
+	}
+
 	var schema *openapi3.Schema
 
 	// 获取基础类型，用于检测自嵌套
@@ -650,10 +948,21 @@ func generateSchemaValue(doc *openapi3.T, t reflect.Type, isMultipart bool) *ope
 					}
 				}
 
+				// 指针字段可以为null
+				if field.Type.Kind() == reflect.Ptr && !isMultipart && schemaRef.Value != nil {
+					schemaRef.Value = nullableSchema(doc, schemaRef.Value)
+				}
 				// 添加字段描述
 				if desc != "" && schemaRef.Value != nil {
 					schemaRef.Value.Description = desc
 				}
+				// 添加校验规则
+				applyValidateRules(schemaRef.Value, field.Tag.Get("validate"))
+				applyEnumTag(schemaRef.Value, field)
+				// OpenAPI 3.0中引用类型的同级字段会被忽略，不添加示例
+				if _, isRef := schemaRef.Value.Extensions["$ref"]; !isRef || isOpenAPI31(doc) {
+					schemaRef.Value.Example = fieldExample(schemaRef.Value, field)
+				}
 				schema.Properties[name] = schemaRef
 				// 如果字段是必需的，添加到Required列表
 				if isRequired {
@@ -674,8 +983,8 @@ func generateSchemaValue(doc *openapi3.T, t reflect.Type, isMultipart bool) *ope
 		if elemType.Kind() == reflect.Struct && elemType.PkgPath() != "" {
 			schemaName := snakeToPascalCase(elemType.PkgPath() + "." + elemType.Name())
 
-			// 如果正在处理这个类型，直接使用引用避免无限递归
-			if processedTypes[schemaName] {
+			// 如果正在处理或已处理过这个类型，直接使用引用避免无限递归
+			if _, exists := doc.Components.Schemas[schemaName]; exists {
 				schema.Items = &openapi3.SchemaRef{
 					Ref: "#/components/schemas/" + schemaName,
 				}
@@ -720,6 +1029,11 @@ func generateSchemaValue(doc *openapi3.T, t reflect.Type, isMultipart bool) *ope
 		schema = openapi3.NewStringSchema()
 	}
 
+	// 实现了Nullable接口的类型可以为null
+	if isNullableType(t) {
+		schema.Nullable = true
+	}
+
 	return schema
 }
 
@@ -729,33 +1043,135 @@ func NewOpenAPIRouter(path string) *OpenAPI {
 	return &OpenAPI{path: path}
 }
 
+// OpenAPI 提供OpenAPI文档的路由
+// Server通过WithOpenAPI启用内存文档时，返回启动时生成的文档，否则返回当前目录下的openapi.json，文件在第一次读取成功后缓存
+// 请求的Accept为YAML或者路径以.yaml、.yml结尾时返回YAML格式，否则返回JSON格式
 type OpenAPI struct {
 	MethodGet
 	NoOpenAPI
 	NoGenParameter
 	path string
+
+	mu           sync.Mutex
+	fileDocument *openAPIDocument // 缓存的openapi.json
 }
 
 func (o *OpenAPI) Path() string {
 	return o.path
 }
 
-func (OpenAPI) Output(ctx context.Context) (any, error) {
-	file, err := os.Open("openapi.json")
+// Output 文档由GinHandle返回，不会调用Output
+func (o *OpenAPI) Output(ctx context.Context) (any, error) {
+	return nil, nil
+}
+
+// GinHandle 返回OpenAPI文档，响应携带ETag，请求的If-None-Match与ETag一致时返回304
+func (o *OpenAPI) GinHandle() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		document := openAPIDocumentFromContext(c.Request.Context())
+		if document == nil {
+			// 未启用内存文档时读取openapi.json
+			var err error
+			if document, err = o.loadFileDocument(); err != nil {
+				renderError(c, err)
+				return
+			}
+		}
+		document.serve(c)
+	}
+}
+
+// loadFileDocument 读取当前目录下的openapi.json，读取成功后缓存，读取失败时下次请求重新读取
+func (o *OpenAPI) loadFileDocument() (*openAPIDocument, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.fileDocument != nil {
+		return o.fileDocument, nil
+	}
+
+	data, err := os.ReadFile("openapi.json")
 	if err != nil {
 		return nil, NewError(500, "open openapi.json error", err.Error())
 	}
-	fileInfo, _ := file.Stat()
+	document, err := newOpenAPIDocumentFromJSON(data)
+	if err != nil {
+		return nil, NewError(500, "parse openapi.json error", err.Error())
+	}
+	o.fileDocument = document
+	return document, nil
+}
+
+// openAPIDocument 序列化后的OpenAPI文档，JSON和YAML格式分别计算ETag
+type openAPIDocument struct {
+	json     []byte
+	yaml     []byte
+	jsonETag string
+	yamlETag string
+}
+
+// newOpenAPIDocument 序列化OpenAPI文档
+func newOpenAPIDocument(doc *openapi3.T) (*openAPIDocument, error) {
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return newOpenAPIDocumentFromJSON(data)
+}
 
-	return &AttachmentFromReader{
-		Disposition:   DispositionInline,
-		ContentType:   "application/json",
-		Filename:      "openapi.json",
-		ContentLength: fileInfo.Size(),
-		Reader:        file,
+// newOpenAPIDocumentFromJSON 根据JSON格式的文档生成YAML格式并计算ETag
+func newOpenAPIDocumentFromJSON(data []byte) (*openAPIDocument, error) {
+	yamlData, err := yaml.JSONToYAML(data)
+	if err != nil {
+		return nil, err
+	}
+	return &openAPIDocument{
+		json:     data,
+		yaml:     yamlData,
+		jsonETag: contentETag(data),
+		yamlETag: contentETag(yamlData),
 	}, nil
 }
 
+// serve 按照请求的Accept或者路径后缀返回JSON或YAML格式的文档
+func (d *openAPIDocument) serve(c *gin.Context) {
+	data, etag, contentType := d.json, d.jsonETag, MIMEJSON
+	if wantsYAML(c.Request) {
+		data, etag, contentType = d.yaml, d.yamlETag, "application/yaml"
+	}
+
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Vary", "Accept")
+	if match := c.GetHeader("If-None-Match"); match != "" && (match == "*" || strings.Contains(match, etag)) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, contentType+"; charset=utf-8", data)
+}
+
+// wantsYAML 判断请求是否需要YAML格式的文档
+func wantsYAML(r *http.Request) bool {
+	if ext := path.Ext(r.URL.Path); ext == ".yaml" || ext == ".yml" {
+		return true
+	}
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		switch mediaType {
+		case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+			return true
+		case MIMEJSON:
+			return false
+		}
+	}
+	return false
+}
+
+// contentETag 根据内容的SHA-256生成强ETag
+func contentETag(data []byte) string {
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
 func NewSwaggerUIRouter(path string) *SwaggerUI {
 	return &SwaggerUI{path: path}
 }
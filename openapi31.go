@@ -0,0 +1,126 @@
+package easygin
+
+import (
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	OpenAPIVersion30 = "3.0.3" // 默认生成的OpenAPI版本
+	OpenAPIVersion31 = "3.1.0" // 使用JSON Schema 2020-12语义的OpenAPI版本
+)
+
+// isOpenAPI31 判断文档是否按照OpenAPI 3.1生成
+func isOpenAPI31(doc *openapi3.T) bool {
+	return strings.HasPrefix(doc.OpenAPI, "3.1")
+}
+
+// convertToOpenAPI31 将按照3.0生成的schema转换为JSON Schema 2020-12的写法
+//   - 引用只保留$ref以及description等注解字段，不再携带type和title
+//   - nullable转换为type: [x, "null"]，引用类型转换为anyOf
+//   - example转换为examples，只有一个值的enum转换为const
+func convertToOpenAPI31(doc *openapi3.T) {
+	visited := make(map[*openapi3.Schema]bool)
+	var walk func(ref *openapi3.SchemaRef)
+	walk = func(ref *openapi3.SchemaRef) {
+		if ref == nil || ref.Value == nil || visited[ref.Value] {
+			return
+		}
+		schema := ref.Value
+		visited[schema] = true
+
+		for _, prop := range schema.Properties {
+			walk(prop)
+		}
+		walk(schema.Items)
+		walk(schema.AdditionalProperties.Schema)
+		walk(schema.Not)
+		for _, refs := range []openapi3.SchemaRefs{schema.AllOf, schema.AnyOf, schema.OneOf} {
+			for _, r := range refs {
+				walk(r)
+			}
+		}
+
+		convertSchemaToOpenAPI31(schema)
+	}
+
+	for _, schema := range doc.Components.Schemas {
+		walk(schema)
+	}
+	for _, pathItem := range doc.Paths.Map() {
+		for _, op := range pathItem.Operations() {
+			for _, param := range op.Parameters {
+				if param.Value != nil {
+					walk(param.Value.Schema)
+				}
+			}
+			if op.RequestBody != nil && op.RequestBody.Value != nil {
+				for _, media := range op.RequestBody.Value.Content {
+					walk(media.Schema)
+				}
+			}
+			for _, resp := range op.Responses.Map() {
+				if resp.Value == nil {
+					continue
+				}
+				for _, media := range resp.Value.Content {
+					walk(media.Schema)
+				}
+			}
+		}
+	}
+}
+
+// convertSchemaToOpenAPI31 转换单个schema，子schema由调用方处理
+func convertSchemaToOpenAPI31(schema *openapi3.Schema) {
+	if schema.Example != nil {
+		setSchemaExtension(schema, "examples", []any{schema.Example})
+		schema.Example = nil
+	}
+
+	if ref, isRef := schema.Extensions["$ref"].(string); isRef {
+		// 引用只保留注解字段，3.1中$ref允许与这些字段并列
+		converted := openapi3.Schema{
+			Description: schema.Description,
+			Deprecated:  schema.Deprecated,
+			ReadOnly:    schema.ReadOnly,
+			WriteOnly:   schema.WriteOnly,
+			Extensions:  schema.Extensions,
+		}
+		if schema.Nullable {
+			delete(converted.Extensions, "$ref")
+			converted.AnyOf = openapi3.SchemaRefs{
+				{Value: &openapi3.Schema{Extensions: map[string]any{"$ref": ref}}},
+				{Value: &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeNull}}},
+			}
+		}
+		*schema = converted
+		return
+	}
+
+	if schema.Nullable {
+		schema.Nullable = false
+		if schema.Type != nil {
+			types := append(openapi3.Types{}, schema.Type.Slice()...)
+			schema.Type = &types
+			*schema.Type = append(*schema.Type, openapi3.TypeNull)
+		}
+		if len(schema.Enum) > 0 {
+			schema.Enum = append(schema.Enum, nil)
+		}
+	}
+
+	if len(schema.Enum) == 1 {
+		setSchemaExtension(schema, "const", schema.Enum[0])
+		schema.Enum = nil
+	}
+}
+
+// setSchemaExtension 设置schema的扩展字段，扩展字段会与schema的其他字段一起输出
+func setSchemaExtension(schema *openapi3.Schema, key string, value any) {
+	if schema.Extensions == nil {
+		schema.Extensions = make(map[string]any)
+	}
+	schema.Extensions[key] = value
+}
@@ -0,0 +1,152 @@
+package easygin
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestGenerateOpenAPI31(t *testing.T) {
+	root := NewRouterGroup("/")
+	root.RegisterAPI(&TestNullableAPI{})
+
+	t.Run("Default30", func(t *testing.T) {
+		doc, err := BuildOpenAPIWithOptions(OpenAPIOptions{Version: "1.0.0"}, root)
+		if err != nil {
+			t.Fatalf("BuildOpenAPIWithOptions returned error: %v", err)
+		}
+		loaded := loadOpenAPIDoc(t, doc)
+		if loaded.OpenAPI != OpenAPIVersion30 {
+			t.Fatalf("expected default version %s, got %s", OpenAPIVersion30, loaded.OpenAPI)
+		}
+		if err := loaded.Validate(context.Background()); err != nil {
+			t.Fatalf("expected valid 3.0 document, got %v", err)
+		}
+		node := nodeSchema(t, loaded)
+		if !node.Properties["note"].Value.Nullable {
+			t.Fatalf("expected nullable pointer field")
+		}
+		// 3.0中引用类型通过allOf包装后标记nullable
+		parent := node.Properties["parent"].Value
+		if !parent.Nullable || len(parent.AllOf) != 1 || parent.AllOf[0].Value != node {
+			t.Fatalf("expected nullable allOf $ref, got %+v", parent)
+		}
+	})
+
+	t.Run("Version31", func(t *testing.T) {
+		doc, err := BuildOpenAPIWithOptions(OpenAPIOptions{OpenAPIVersion: OpenAPIVersion31, Version: "1.0.0"}, root)
+		if err != nil {
+			t.Fatalf("BuildOpenAPIWithOptions returned error: %v", err)
+		}
+		loaded := loadOpenAPIDoc(t, doc)
+		if loaded.OpenAPI != OpenAPIVersion31 {
+			t.Fatalf("expected version %s, got %s", OpenAPIVersion31, loaded.OpenAPI)
+		}
+
+		node := nodeSchema(t, loaded)
+		if types := node.Properties["note"].Value.Type.Slice(); !reflect.DeepEqual(types, []string{"string", "null"}) {
+			t.Fatalf("expected nullable pointer field, got %v", types)
+		}
+		if types := node.Properties["name"].Value.Type.Slice(); !reflect.DeepEqual(types, []string{"string"}) {
+			t.Fatalf("expected non-nullable field, got %v", types)
+		}
+
+		// loader解析后引用指向组件定义
+		const ref = "#/components/schemas/GithubComZboycoEasyginTestNode"
+		parent := node.Properties["parent"].Value
+		if len(parent.AnyOf) != 2 || parent.AnyOf[0].Ref != ref || parent.AnyOf[0].Value != node ||
+			!parent.AnyOf[1].Value.Type.Is(openapi3.TypeNull) || parent.Description != "父节点" {
+			t.Fatalf("expected nullable $ref via anyOf, got %+v", parent)
+		}
+		if children := node.Properties["children"].Value.Items; children.Ref != ref || children.Value != node {
+			t.Fatalf("expected resolved $ref, got %+v", children)
+		}
+		body := loaded.Paths.Value("/nodes").Post.RequestBody.Value.Content.Get(MIMEJSON).Schema
+		if body.Ref != ref || body.Value != node {
+			t.Fatalf("expected request body $ref, got %+v", body)
+		}
+
+		// 自嵌套引用只保留$ref，不再携带type和title
+		self := nodeSchema(t, doc).Properties["parent"].Value.AnyOf[0].Value
+		if self.Extensions["$ref"] != ref || self.Type != nil || self.Title != "" {
+			t.Fatalf("expected plain $ref without type and title, got %+v", self)
+		}
+	})
+
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		if _, err := BuildOpenAPIWithOptions(OpenAPIOptions{OpenAPIVersion: "2.0"}, root); err == nil {
+			t.Fatal("expected error for unsupported version")
+		}
+	})
+}
+
+func TestConvertSchemaToOpenAPI31(t *testing.T) {
+	schema := openapi3.NewStringSchema()
+	schema.Nullable = true
+	schema.Enum = []any{"on"}
+	schema.Example = "on"
+	convertSchemaToOpenAPI31(schema)
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"enum":["on",null],"examples":["on"],"type":["string","null"]}`
+	if string(data) != expected {
+		t.Fatalf("expected %s, got %s", expected, data)
+	}
+
+	schema = openapi3.NewStringSchema()
+	schema.Enum = []any{"on"}
+	convertSchemaToOpenAPI31(schema)
+	if schema.Extensions["const"] != "on" || schema.Enum != nil {
+		t.Fatalf("expected single enum converted to const, got %+v", schema)
+	}
+}
+
+// loadOpenAPIDoc 将文档序列化后通过kin-openapi的loader重新加载，并解析所有引用
+func loadOpenAPIDoc(t *testing.T, doc *openapi3.T) *openapi3.T {
+	t.Helper()
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("marshal document failed: %v", err)
+	}
+	loaded, err := openapi3.NewLoader().LoadFromData(data)
+	if err != nil {
+		t.Fatalf("load document failed: %v", err)
+	}
+	return loaded
+}
+
+func nodeSchema(t *testing.T, doc *openapi3.T) *openapi3.Schema {
+	t.Helper()
+	ref := doc.Components.Schemas["GithubComZboycoEasyginTestNode"]
+	if ref == nil || ref.Value == nil {
+		t.Fatalf("expected TestNode component, got %v", doc.Components.Schemas)
+	}
+	return ref.Value
+}
+
+// TestNullableAPI 用于测试OpenAPI 3.1的nullable输出
+type TestNullableAPI struct {
+	MethodPost
+	Body TestNode `in:"body"`
+}
+
+type TestNode struct {
+	Name     string      `json:"name"`
+	Note     *string     `json:"note"`
+	Parent   *TestNode   `json:"parent" desc:"父节点"`
+	Children []*TestNode `json:"children"`
+}
+
+func (TestNullableAPI) Path() string {
+	return "/nodes"
+}
+
+func (api *TestNullableAPI) Output(ctx context.Context) (any, error) {
+	return api.Body, nil
+}
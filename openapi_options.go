@@ -0,0 +1,148 @@
+package easygin
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// OpenAPIOptions 生成OpenAPI文档时使用的元数据，未设置的字段使用默认值或者不输出
+type OpenAPIOptions struct {
+	OpenAPIVersion  string                        // 文档使用的OpenAPI版本，支持OpenAPIVersion30和OpenAPIVersion31，默认为3.0.3
+	Title           string                        // 文档标题，默认为RESTful API
+	Version         string                        // API版本，如1.0.0
+	Description     string                        // 文档描述，支持Markdown
+	Contact         *openapi3.Contact             // 联系人信息
+	License         *openapi3.License             // 许可证信息
+	Servers         openapi3.Servers              // 服务地址列表
+	ExternalDocs    *openapi3.ExternalDocs        // 外部文档
+	SecuritySchemes openapi3.SecuritySchemes      // 安全认证方式，键为名称，中间件通过OpenAPISecurity按名称引用
+	Security        openapi3.SecurityRequirements // 所有API默认的安全要求，中间件声明的安全要求会覆盖该值
+}
+
+// OpenAPISecurity 定义了中间件声明安全要求的接口
+// 路由组中的中间件实现此接口后，组内及子组中的API在OpenAPI文档中自动标记对应的security，
+// 返回的多个安全要求之间为“或”的关系，同一路由链路上多个中间件的安全要求之间为“与”的关系
+type OpenAPISecurity interface {
+	OpenAPISecurity() openapi3.SecurityRequirements
+}
+
+// SecurityRequirement 创建引用单个安全认证方式的安全要求，scopes为OAuth2需要的权限范围
+func SecurityRequirement(name string, scopes ...string) openapi3.SecurityRequirements {
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return openapi3.SecurityRequirements{openapi3.SecurityRequirement{name: scopes}}
+}
+
+// NewBearerSecurityScheme 创建HTTP Bearer认证方式，bearerFormat为令牌格式，如JWT
+func NewBearerSecurityScheme(bearerFormat string) *openapi3.SecuritySchemeRef {
+	return &openapi3.SecuritySchemeRef{Value: &openapi3.SecurityScheme{
+		Type:         "http",
+		Scheme:       "bearer",
+		BearerFormat: bearerFormat,
+	}}
+}
+
+// NewAPIKeySecurityScheme 创建API Key认证方式，in为header、query或cookie，name为参数名称
+func NewAPIKeySecurityScheme(in, name string) *openapi3.SecuritySchemeRef {
+	return &openapi3.SecuritySchemeRef{Value: &openapi3.SecurityScheme{
+		Type: "apiKey",
+		In:   in,
+		Name: name,
+	}}
+}
+
+// NewOAuth2SecurityScheme 创建OAuth2认证方式
+func NewOAuth2SecurityScheme(flows *openapi3.OAuthFlows) *openapi3.SecuritySchemeRef {
+	return &openapi3.SecuritySchemeRef{Value: &openapi3.SecurityScheme{
+		Type:  "oauth2",
+		Flows: flows,
+	}}
+}
+
+// apply 将元数据写入文档
+func (o OpenAPIOptions) apply(doc *openapi3.T) error {
+	switch o.OpenAPIVersion {
+	case "":
+	case OpenAPIVersion30, OpenAPIVersion31:
+		doc.OpenAPI = o.OpenAPIVersion
+	default:
+		return fmt.Errorf("unsupported OpenAPI version %q, expected %q or %q", o.OpenAPIVersion, OpenAPIVersion30, OpenAPIVersion31)
+	}
+	if o.Title != "" {
+		doc.Info.Title = o.Title
+	}
+	doc.Info.Version = o.Version
+	doc.Info.Description = o.Description
+	doc.Info.Contact = o.Contact
+	doc.Info.License = o.License
+	doc.Servers = o.Servers
+	doc.ExternalDocs = o.ExternalDocs
+	if len(o.SecuritySchemes) > 0 {
+		doc.Components.SecuritySchemes = o.SecuritySchemes
+	}
+	if len(o.Security) > 0 {
+		doc.Security = o.Security
+	}
+	return nil
+}
+
+// validateSecurity 检查文档和操作中引用的安全认证方式是否都已定义
+func validateSecurity(doc *openapi3.T) error {
+	check := func(requirements openapi3.SecurityRequirements, where string) error {
+		for _, requirement := range requirements {
+			names := make([]string, 0, len(requirement))
+			for name := range requirement {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				if doc.Components.SecuritySchemes[name] == nil {
+					return fmt.Errorf("security scheme %q used by %s is not defined in OpenAPIOptions.SecuritySchemes", name, where)
+				}
+			}
+		}
+		return nil
+	}
+
+	if err := check(doc.Security, "document"); err != nil {
+		return err
+	}
+	for path, pathItem := range doc.Paths.Map() {
+		for method, op := range pathItem.Operations() {
+			if op.Security == nil {
+				continue
+			}
+			if err := check(*op.Security, method+" "+path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// mergeSecurity 合并同一路由链路上多个中间件的安全要求，结果需要同时满足两者
+func mergeSecurity(a, b openapi3.SecurityRequirements) openapi3.SecurityRequirements {
+	if len(a) == 0 {
+		return b
+	}
+	if len(b) == 0 {
+		return a
+	}
+	merged := make(openapi3.SecurityRequirements, 0, len(a)*len(b))
+	for _, ra := range a {
+		for _, rb := range b {
+			requirement := openapi3.SecurityRequirement{}
+			for name, scopes := range ra {
+				requirement[name] = scopes
+			}
+			for name, scopes := range rb {
+				requirement[name] = append(append([]string{}, requirement[name]...), scopes...)
+			}
+			merged = append(merged, requirement)
+		}
+	}
+	return merged
+}
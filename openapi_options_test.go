@@ -0,0 +1,88 @@
+package easygin
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestBuildOpenAPIWithOptions(t *testing.T) {
+	root := NewRouterGroup("/", &testSecurityMiddleware{})
+	root.RegisterAPI(&TestPatchAPI{})
+	admin := NewRouterGroup("/admin", &testScopeMiddleware{})
+	admin.RegisterAPI(&TestAnyAPI{})
+	root.RegisterGroup(admin)
+	public := NewRouterGroup("/public")
+	public.RegisterAPI(&TestClientAPI{})
+
+	opts := OpenAPIOptions{
+		Title:   "Test API",
+		Version: "1.2.3",
+		Servers: openapi3.Servers{{URL: "https://api.example.com"}},
+		License: &openapi3.License{Name: "MIT"},
+		SecuritySchemes: openapi3.SecuritySchemes{
+			"bearerAuth": NewBearerSecurityScheme("JWT"),
+			"oauth":      NewOAuth2SecurityScheme(&openapi3.OAuthFlows{}),
+		},
+	}
+	doc, err := BuildOpenAPIWithOptions(opts, root, public)
+	if err != nil {
+		t.Fatalf("BuildOpenAPIWithOptions returned error: %v", err)
+	}
+
+	if doc.Info.Title != "Test API" || doc.Info.Version != "1.2.3" || doc.Info.License.Name != "MIT" || doc.Servers[0].URL != "https://api.example.com" {
+		t.Fatalf("expected document metadata from options, got %+v %+v", doc.Info, doc.Servers)
+	}
+	if doc.Components.SecuritySchemes["bearerAuth"].Value.Scheme != "bearer" {
+		t.Fatalf("expected bearer security scheme, got %+v", doc.Components.SecuritySchemes)
+	}
+
+	patch := doc.Paths.Value("/methods").Patch
+	if patch.Security == nil || !reflect.DeepEqual(*patch.Security, SecurityRequirement("bearerAuth")) {
+		t.Fatalf("expected bearer security requirement, got %+v", patch.Security)
+	}
+
+	// 子组的中间件与父级中间件的安全要求需要同时满足
+	expected := openapi3.SecurityRequirements{{"bearerAuth": {}, "oauth": {"admin"}}}
+	get := doc.Paths.Value("/admin/any").Get
+	if get.Security == nil || !reflect.DeepEqual(*get.Security, expected) {
+		t.Fatalf("expected merged security requirement, got %+v", get.Security)
+	}
+
+	if op := doc.Paths.Value("/public/items/{id}").Post; op.Security != nil {
+		t.Fatalf("expected public api without security, got %+v", op.Security)
+	}
+}
+
+func TestBuildOpenAPIUndefinedSecurityScheme(t *testing.T) {
+	root := NewRouterGroup("/", &testSecurityMiddleware{})
+	root.RegisterAPI(&TestPatchAPI{})
+
+	_, err := BuildOpenAPI(root)
+	if err == nil || !strings.Contains(err.Error(), `security scheme "bearerAuth" used by PATCH /methods is not defined`) {
+		t.Fatalf("expected undefined security scheme error, got %v", err)
+	}
+}
+
+type testSecurityMiddleware struct{}
+
+func (testSecurityMiddleware) OpenAPISecurity() openapi3.SecurityRequirements {
+	return SecurityRequirement("bearerAuth")
+}
+
+func (testSecurityMiddleware) Output(ctx context.Context) (any, error) {
+	return nil, nil
+}
+
+type testScopeMiddleware struct{}
+
+func (testScopeMiddleware) OpenAPISecurity() openapi3.SecurityRequirements {
+	return SecurityRequirement("oauth", "admin")
+}
+
+func (testScopeMiddleware) Output(ctx context.Context) (any, error) {
+	return nil, nil
+}
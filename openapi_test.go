@@ -0,0 +1,354 @@
+package easygin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestProcessStructFieldsRequestBodyContentType(t *testing.T) {
+	doc := &openapi3.T{
+		Paths:      openapi3.NewPaths(),
+		Components: &openapi3.Components{Schemas: openapi3.Schemas{}},
+	}
+
+	op := &openapi3.Operation{}
+	processStructFields(doc, reflect.TypeOf(TestURLEncodedHandler{}), op, map[reflect.Type]bool{})
+
+	media := op.RequestBody.Value.Content.Get("application/x-www-form-urlencoded")
+	if media == nil {
+		t.Fatalf("expected urlencoded request body, got %+v", op.RequestBody.Value.Content)
+	}
+	schema := doc.Components.Schemas["GithubComZboycoEasyginTestURLEncodedBody"]
+	if schema == nil {
+		t.Fatalf("expected urlencoded body schema to be registered, got %v", doc.Components.Schemas)
+	}
+	if schema.Value.Properties["grant_type"] == nil || schema.Value.Properties["scope"] == nil {
+		t.Fatalf("expected form field names from name tags, got %+v", schema.Value.Properties)
+	}
+}
+
+func TestRequestBodyContentCodecs(t *testing.T) {
+	doc := &openapi3.T{Components: &openapi3.Components{Schemas: openapi3.Schemas{}}}
+
+	content := requestBodyContent(doc, reflect.TypeOf(TestCodecHandler{}).Field(1))
+	for _, mediaType := range []string{MIMEJSON, MIMEXML, MIMEMsgPack} {
+		if content.Get(mediaType) == nil {
+			t.Fatalf("expected %s in request body content, got %v", mediaType, content)
+		}
+	}
+	if content.Get(MIMEProtobuf) != nil {
+		t.Fatalf("expected protobuf to be skipped for plain struct, got %v", content)
+	}
+
+	type restricted struct {
+		Body TestCodecBody `in:"body" mime:"xml"`
+	}
+	content = requestBodyContent(doc, reflect.TypeOf(restricted{}).Field(0))
+	if len(content) != 1 || content.Get(MIMEXML) == nil {
+		t.Fatalf("expected only xml in restricted request body content, got %v", content)
+	}
+}
+
+func TestServeOpenAPIFromMemory(t *testing.T) {
+	srv := NewServer("test", ":0", false).WithOpenAPI()
+	root := NewRouterGroup("/")
+	root.RegisterAPI(NewOpenAPIRouter("/openapi"))
+	root.RegisterAPI(NewOpenAPIRouter("/openapi.yaml"))
+	root.RegisterAPI(&TestClientAPI{})
+	srv.setup(root)
+
+	request := func(path string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for key, values := range header {
+			req.Header[key] = values
+		}
+		w := httptest.NewRecorder()
+		srv.engine.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request("/openapi", nil)
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), MIMEJSON) {
+		t.Fatalf("expected JSON document, got %d %s", w.Code, w.Header().Get("Content-Type"))
+	}
+	var doc openapi3.T
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON document: %v", err)
+	}
+	if doc.Paths.Value("/items/{id}") == nil {
+		t.Fatalf("expected registered api in document, got %v", doc.Paths.Map())
+	}
+
+	etag := w.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("expected ETag header")
+	}
+	if w := request("/openapi", http.Header{"If-None-Match": {etag}}); w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Fatalf("expected 304 for matching ETag, got %d", w.Code)
+	}
+
+	for _, w := range []*httptest.ResponseRecorder{
+		request("/openapi", http.Header{"Accept": {"application/yaml"}}),
+		request("/openapi.yaml", nil),
+	} {
+		if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "application/yaml") {
+			t.Fatalf("expected YAML document, got %d %s", w.Code, w.Header().Get("Content-Type"))
+		}
+		if !strings.Contains(w.Body.String(), "openapi: 3.0.3") || w.Header().Get("ETag") == etag {
+			t.Fatalf("expected YAML body with its own ETag, got:\n%s", w.Body.String())
+		}
+	}
+}
+
+func TestServeOpenAPIFromFile(t *testing.T) {
+	wd, _ := os.Getwd()
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("chdir failed: %v", err)
+	}
+	defer os.Chdir(wd)
+
+	srv := NewServer("test", ":0", false)
+	root := NewRouterGroup("/")
+	root.RegisterAPI(NewOpenAPIRouter("/openapi"))
+	srv.setup(root)
+
+	request := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		srv.engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi", nil))
+		return w
+	}
+
+	// 文件不存在时返回错误，生成文件后重新读取
+	if w := request(); w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500 without openapi.json, got %d", w.Code)
+	}
+	if err := os.WriteFile("openapi.json", []byte(`{"openapi":"3.0.3"}`), 0o644); err != nil {
+		t.Fatalf("write openapi.json failed: %v", err)
+	}
+	w := request()
+	if w.Code != http.StatusOK || w.Body.String() != `{"openapi":"3.0.3"}` {
+		t.Fatalf("expected document from file, got %d %s", w.Code, w.Body.String())
+	}
+
+	// 读取成功后缓存，不再每次请求读取文件
+	if err := os.Remove("openapi.json"); err != nil {
+		t.Fatalf("remove openapi.json failed: %v", err)
+	}
+	if cached := request(); cached.Code != http.StatusOK || cached.Header().Get("ETag") != w.Header().Get("ETag") {
+		t.Fatalf("expected cached document, got %d %s", cached.Code, cached.Body.String())
+	}
+}
+
+func TestBuildOpenAPIConcurrent(t *testing.T) {
+	root := NewRouterGroup("/")
+	root.RegisterAPI(&TestClientAPI{})
+	root.RegisterAPI(&TestPatchAPI{})
+
+	expected, err := BuildOpenAPI(root)
+	if err != nil {
+		t.Fatalf("BuildOpenAPI returned error: %v", err)
+	}
+	expectedJSON, _ := json.Marshal(expected)
+
+	// 每次生成使用独立的状态，并发生成的文档与单独生成的一致
+	var wg sync.WaitGroup
+	results := make([][]byte, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			doc, err := BuildOpenAPI(root)
+			if err != nil {
+				t.Errorf("BuildOpenAPI returned error: %v", err)
+				return
+			}
+			results[i], _ = json.Marshal(doc)
+		}(i)
+	}
+	wg.Wait()
+	for _, result := range results {
+		if string(result) != string(expectedJSON) {
+			t.Fatalf("concurrent build differs:\n%s\nexpected:\n%s", result, expectedJSON)
+		}
+	}
+}
+
+func TestBuildAndSaveOpenAPI(t *testing.T) {
+	root := NewRouterGroup("/")
+	root.RegisterAPI(&TestPatchAPI{})
+
+	doc, err := BuildOpenAPI(root)
+	if err != nil {
+		t.Fatalf("BuildOpenAPI returned error: %v", err)
+	}
+	file := filepath.Join(t.TempDir(), "openapi.json")
+	if err := SaveOpenAPI(doc, file); err != nil {
+		t.Fatalf("SaveOpenAPI returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("read saved document failed: %v", err)
+	}
+	var saved openapi3.T
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatalf("decode saved document failed: %v", err)
+	}
+	if saved.Paths.Value("/methods") == nil || saved.Paths.Value("/methods").Patch == nil {
+		t.Fatalf("expected saved document to contain PATCH /methods, got %s", data)
+	}
+}
+
+func TestGenerateGroupPathsMethods(t *testing.T) {
+	doc := &openapi3.T{
+		Paths:      openapi3.NewPaths(),
+		Components: &openapi3.Components{Schemas: openapi3.Schemas{}},
+	}
+
+	root := NewRouterGroup("/")
+	root.RegisterAPI(&TestPatchAPI{})
+	root.RegisterAPI(&TestAnyAPI{})
+	if err := generateGroupPaths(doc, JSONErrorRenderer{}, root, "", nil); err != nil {
+		t.Fatalf("generateGroupPaths returned error: %v", err)
+	}
+
+	patch := doc.Paths.Value("/methods").Patch
+	if patch == nil || patch.OperationID != "testPatchAPI" || patch.Summary != "部分更新" {
+		t.Fatalf("expected PATCH operation with summary, got %+v", patch)
+	}
+
+	anyItem := doc.Paths.Value("/any")
+	operations := anyItem.Operations()
+	if len(operations) != len(anyMethods) {
+		t.Fatalf("expected MethodAny to expand to %d operations, got %v", len(anyMethods), operations)
+	}
+	if anyItem.Trace == nil || anyItem.Trace.OperationID != "testAnyAPITrace" || anyItem.Get.OperationID != "testAnyAPIGet" {
+		t.Fatalf("expected operationId with method suffix, got %+v", operations)
+	}
+}
+
+func TestGenerateGroupPathsDuplicateOperation(t *testing.T) {
+	doc := &openapi3.T{
+		Paths:      openapi3.NewPaths(),
+		Components: &openapi3.Components{Schemas: openapi3.Schemas{}},
+	}
+
+	root := NewRouterGroup("/")
+	root.RegisterAPI(&TestPatchAPI{})
+	root.RegisterAPI(&TestPatchAPI{})
+	err := generateGroupPaths(doc, JSONErrorRenderer{}, root, "", nil)
+	if err == nil || !strings.Contains(err.Error(), "duplicate operation PATCH /methods") {
+		t.Fatalf("expected duplicate operation error, got %v", err)
+	}
+}
+
+func TestGenerateOperationMetadata(t *testing.T) {
+	doc := &openapi3.T{
+		Paths:      openapi3.NewPaths(),
+		Components: &openapi3.Components{Schemas: openapi3.Schemas{}},
+	}
+
+	root := NewRouterGroup("/")
+	root.RegisterAPI(&TestMetadataAPI{})
+	if err := generateGroupPaths(doc, JSONErrorRenderer{}, root, "", nil); err != nil {
+		t.Fatalf("generateGroupPaths returned error: %v", err)
+	}
+
+	op := doc.Paths.Value("/metadata").Post
+	if op.Summary != "旧版接口" || op.Description != "使用 **/v2/metadata** 代替" || !op.Deprecated {
+		t.Fatalf("expected summary, description and deprecated, got %+v", op)
+	}
+	if param := op.Parameters.GetByInAndName("query", "page"); param == nil || param.Example != int64(2) {
+		t.Fatalf("expected typed parameter example, got %+v", param)
+	}
+
+	body := doc.Components.Schemas["GithubComZboycoEasyginTestMetadataBody"].Value
+	if example := body.Properties["name"].Value.Example; example != "easygin" {
+		t.Fatalf("expected string example, got %v", example)
+	}
+	if example := body.Properties["tags"].Value.Example; !reflect.DeepEqual(example, []any{"a", "b"}) {
+		t.Fatalf("expected array example parsed as JSON, got %v", example)
+	}
+
+	media := op.Responses.Value("200").Value.Content.Get(MIMEJSON)
+	if !reflect.DeepEqual(media.Example, TestMetadataBody{Name: "easygin", Tags: []string{"a"}}) {
+		t.Fatalf("expected response example, got %+v", media.Example)
+	}
+
+	// 示例对应的状态码必须声明了响应内容
+	root = NewRouterGroup("/")
+	root.RegisterAPI(&TestAnyExampleAPI{})
+	err := generateGroupPaths(&openapi3.T{Paths: openapi3.NewPaths(), Components: &openapi3.Components{Schemas: openapi3.Schemas{}}}, JSONErrorRenderer{}, root, "", nil)
+	if err == nil || !strings.Contains(err.Error(), "example for status 201") {
+		t.Fatalf("expected undeclared example error, got %v", err)
+	}
+}
+
+type TestMetadataAPI struct {
+	MethodPost `summary:"旧版接口" description:"使用 **/v2/metadata** 代替" deprecated:"true"`
+	Page       int              `in:"query" name:"page,omitempty" example:"2"`
+	Body       TestMetadataBody `in:"body"`
+}
+
+type TestMetadataBody struct {
+	Name string   `json:"name" example:"easygin"`
+	Tags []string `json:"tags" example:"[\"a\",\"b\"]"`
+}
+
+func (TestMetadataAPI) Path() string {
+	return "/metadata"
+}
+
+func (TestMetadataAPI) Responses() R {
+	return R{http.StatusOK: &TestMetadataBody{}}
+}
+
+func (TestMetadataAPI) Examples() map[int]any {
+	return map[int]any{http.StatusOK: TestMetadataBody{Name: "easygin", Tags: []string{"a"}}}
+}
+
+func (TestMetadataAPI) Output(ctx context.Context) (any, error) {
+	return nil, nil
+}
+
+type TestAnyExampleAPI struct {
+	TestAnyAPI
+}
+
+func (TestAnyExampleAPI) Examples() map[int]any {
+	return map[int]any{http.StatusCreated: "created"}
+}
+
+type TestPatchAPI struct {
+	MethodPatch `summary:"部分更新"`
+}
+
+func (TestPatchAPI) Path() string {
+	return "/methods"
+}
+
+func (TestPatchAPI) Output(ctx context.Context) (any, error) {
+	return nil, nil
+}
+
+type TestAnyAPI struct {
+	MethodAny
+}
+
+func (TestAnyAPI) Path() string {
+	return "/any"
+}
+
+func (TestAnyAPI) Output(ctx context.Context) (any, error) {
+	return nil, nil
+}
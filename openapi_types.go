@@ -0,0 +1,111 @@
+package easygin
+
+import (
+	"encoding"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Nullable 标记接口，实现此接口的类型在OpenAPI文档中标记为nullable
+// 用于自定义的可空类型，如序列化时可能输出null的包装类型
+type Nullable interface {
+	OpenAPINullable()
+}
+
+// OpenAPISchemaProvider 定义了类型自定义OpenAPI schema的接口
+// 序列化格式与Go类型不一致的类型（如枚举、金额、ID）实现此接口后，文档中直接使用返回的schema
+type OpenAPISchemaProvider interface {
+	OpenAPISchema() *openapi3.Schema
+}
+
+var (
+	nullableType       = reflect.TypeOf((*Nullable)(nil)).Elem()
+	schemaProviderType = reflect.TypeOf((*OpenAPISchemaProvider)(nil)).Elem()
+	textMarshalerType  = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonMarshalerType  = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	rawMessageType     = reflect.TypeOf(json.RawMessage{})
+	durationType       = reflect.TypeOf(time.Duration(0))
+	bigIntType         = reflect.TypeOf(big.Int{})
+)
+
+// implements 判断类型或其指针是否实现了接口
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || (t.Kind() != reflect.Ptr && reflect.PointerTo(t).Implements(iface))
+}
+
+// providedSchema 获取实现了OpenAPISchemaProvider接口的类型自定义的schema，其他类型返回nil
+// 返回schema的副本，添加字段描述等修改不会影响类型返回的schema
+func providedSchema(t reflect.Type) *openapi3.Schema {
+	if t.Kind() == reflect.Ptr || !implements(t, schemaProviderType) {
+		return nil
+	}
+	schema := reflect.New(t).Interface().(OpenAPISchemaProvider).OpenAPISchema()
+	if schema == nil {
+		return nil
+	}
+	copied := *schema
+	return &copied
+}
+
+// isNullableType 判断类型或其指针是否实现了Nullable接口
+func isNullableType(t reflect.Type) bool {
+	return implements(t, nullableType)
+}
+
+// sqlNullValueType 获取database/sql中Null*类型包装的值类型，如sql.NullString包装string
+func sqlNullValueType(t reflect.Type) (reflect.Type, bool) {
+	if t.Kind() != reflect.Struct || t.PkgPath() != "database/sql" || !strings.HasPrefix(t.Name(), "Null") || t.NumField() != 2 {
+		return nil, false
+	}
+	return t.Field(0).Type, true
+}
+
+// wellKnownSchema 为序列化格式与反射结构不一致的常用类型生成schema，其他类型返回nil
+func wellKnownSchema(doc *openapi3.T, t reflect.Type, isMultipart bool) *openapi3.Schema {
+	if valueType, ok := sqlNullValueType(t); ok {
+		return nullableSchema(doc, generateSchema(doc, valueType, isMultipart))
+	}
+
+	switch {
+	case t == rawMessageType:
+		// 任意JSON值
+		return &openapi3.Schema{}
+	case t == durationType:
+		// time.Duration序列化为纳秒数
+		schema := openapi3.NewInt64Schema()
+		schema.Description = "时长，单位为纳秒"
+		return schema
+	case t == bigIntType:
+		// big.Int序列化为任意精度的整数
+		return openapi3.NewIntegerSchema()
+	case t.Kind() == reflect.Array && t.Len() == 16 && t.Elem().Kind() == reflect.Uint8 &&
+		implements(t, textMarshalerType) && !implements(t, jsonMarshalerType):
+		// 通过TextMarshaler序列化为字符串的[16]byte通常为UUID，未实现时序列化为数字数组
+		return openapi3.NewUUIDSchema()
+	case implements(t, textMarshalerType):
+		// 实现了encoding.TextMarshaler的类型序列化为字符串，如net.IP
+		return openapi3.NewStringSchema()
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		// []byte序列化为base64字符串
+		return openapi3.NewBytesSchema()
+	}
+	return nil
+}
+
+// nullableSchema 将字段的schema标记为nullable
+// OpenAPI 3.0中$ref的同级字段会被忽略，引用类型通过allOf包装后再标记
+func nullableSchema(doc *openapi3.T, schema *openapi3.Schema) *openapi3.Schema {
+	if _, isRef := schema.Extensions["$ref"]; isRef && !isOpenAPI31(doc) {
+		return &openapi3.Schema{
+			Nullable: true,
+			AllOf:    openapi3.SchemaRefs{{Value: schema}},
+		}
+	}
+	schema.Nullable = true
+	return schema
+}
@@ -0,0 +1,144 @@
+package easygin
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestGenerateSchemaNullableAndWellKnownTypes(t *testing.T) {
+	doc := &openapi3.T{
+		Paths:      openapi3.NewPaths(),
+		Components: &openapi3.Components{Schemas: openapi3.Schemas{}},
+	}
+
+	schema := generateSchemaValue(doc, reflect.TypeOf(TestWellKnownTypes{}), false)
+	cases := []struct {
+		name     string
+		typ      string
+		format   string
+		nullable bool
+	}{
+		{"count", openapi3.TypeInteger, "", false},
+		{"limit", openapi3.TypeInteger, "", true},
+		{"nickname", openapi3.TypeString, "", true},
+		{"score", openapi3.TypeNumber, "", true},
+		{"deleted_at", openapi3.TypeString, "date-time", true},
+		{"age", openapi3.TypeInteger, "", true},
+		{"avatar", openapi3.TypeString, "byte", false},
+		{"timeout", openapi3.TypeInteger, "int64", false},
+		{"id", openapi3.TypeString, "uuid", false},
+		{"raw_id", openapi3.TypeArray, "", false},
+		{"balance", openapi3.TypeInteger, "", true},
+		{"money", openapi3.TypeInteger, "", false},
+	}
+	for _, c := range cases {
+		prop := schema.Properties[c.name].Value
+		if !prop.Type.Is(c.typ) || prop.Format != c.format || prop.Nullable != c.nullable {
+			t.Errorf("unexpected schema for %s: type=%v format=%q nullable=%v", c.name, prop.Type, prop.Format, prop.Nullable)
+		}
+	}
+
+	if raw := schema.Properties["raw"].Value; raw.Type != nil {
+		t.Errorf("expected json.RawMessage to accept any value, got %v", raw.Type)
+	}
+
+	// 实现Nullable接口的类型在组件定义中标记nullable
+	component := doc.Components.Schemas["GithubComZboycoEasyginTestNullableName"]
+	if component == nil || !component.Value.Nullable {
+		t.Fatalf("expected nullable component, got %+v", component)
+	}
+}
+
+func TestGenerateSchemaProviderAndTextMarshaler(t *testing.T) {
+	doc := &openapi3.T{
+		Paths:      openapi3.NewPaths(),
+		Components: &openapi3.Components{Schemas: openapi3.Schemas{}},
+	}
+
+	schema := generateSchemaValue(doc, reflect.TypeOf(TestCustomTypes{}), false)
+
+	price := schema.Properties["price"].Value
+	if !price.Type.Is(openapi3.TypeString) || price.Pattern != `^\d+\.\d{2}$` || price.Description != "价格" {
+		t.Fatalf("expected schema from OpenAPISchemaProvider, got %+v", price)
+	}
+	// 字段描述不影响类型返回的schema
+	if (TestMoney{}).OpenAPISchema().Description != "" {
+		t.Fatalf("expected provider schema to be copied")
+	}
+	if discount := schema.Properties["discount"].Value; !discount.Nullable || discount.Pattern == "" {
+		t.Fatalf("expected nullable provider schema for pointer field, got %+v", discount)
+	}
+	if _, ok := doc.Components.Schemas["GithubComZboycoEasyginTestMoney"]; ok {
+		t.Fatalf("expected provider schema to be inlined")
+	}
+
+	for _, name := range []string{"level", "ip"} {
+		if prop := schema.Properties[name].Value; !prop.Type.Is(openapi3.TypeString) || prop.Format != "" {
+			t.Errorf("expected TextMarshaler %s to be string, got %v %q", name, prop.Type, prop.Format)
+		}
+	}
+}
+
+type TestCustomTypes struct {
+	Price    TestMoney  `json:"price" desc:"价格"`
+	Discount *TestMoney `json:"discount"`
+	Level    TestLevel  `json:"level"`
+	IP       net.IP     `json:"ip"`
+}
+
+// TestMoney 以字符串形式序列化的金额
+type TestMoney struct {
+	cents int64
+}
+
+func (TestMoney) OpenAPISchema() *openapi3.Schema {
+	schema := openapi3.NewStringSchema()
+	schema.Pattern = `^\d+\.\d{2}$`
+	return schema
+}
+
+// TestLevel 通过TextMarshaler序列化为字符串的枚举
+type TestLevel int
+
+func (l TestLevel) MarshalText() ([]byte, error) {
+	return []byte([]string{"low", "high"}[l]), nil
+}
+
+type TestWellKnownTypes struct {
+	Count     int              `json:"count"`
+	Limit     *int             `json:"limit"`
+	Nickname  sql.NullString   `json:"nickname"`
+	Score     sql.NullFloat64  `json:"score"`
+	DeletedAt sql.NullTime     `json:"deleted_at"`
+	Age       sql.Null[int64]  `json:"age"`
+	Raw       json.RawMessage  `json:"raw"`
+	Avatar    []byte           `json:"avatar"`
+	Timeout   time.Duration    `json:"timeout"`
+	ID        TestUUID         `json:"id"`
+	RawID     [16]byte         `json:"raw_id"`
+	Balance   *big.Int         `json:"balance"`
+	Money     big.Int          `json:"money"`
+	Name      TestNullableName `json:"name"`
+}
+
+// TestUUID 通过TextMarshaler序列化为字符串的UUID
+type TestUUID [16]byte
+
+func (u TestUUID) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:])), nil
+}
+
+// TestNullableName 用于测试Nullable标记接口
+type TestNullableName struct {
+	First string `json:"first"`
+}
+
+func (TestNullableName) OpenAPINullable() {}
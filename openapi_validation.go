@@ -0,0 +1,227 @@
+package easygin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gin-gonic/gin"
+	"github.com/zboyco/easygin/logr"
+)
+
+// OpenAPIValidationMode 定义了根据OpenAPI文档校验请求和响应的方式
+type OpenAPIValidationMode int
+
+const (
+	// OpenAPIValidationOff 不校验请求和响应
+	OpenAPIValidationOff OpenAPIValidationMode = iota
+	// OpenAPIValidationLog 校验请求和响应，不一致时只记录警告日志，不影响请求处理
+	OpenAPIValidationLog
+	// OpenAPIValidationStrict 校验请求和响应，请求不一致时返回400，响应不一致时丢弃原响应并返回500
+	OpenAPIValidationStrict
+)
+
+// newOpenAPIValidator 根据注册的路由组生成OpenAPI文档，返回使用文档校验请求和响应的中间件
+// 未出现在文档中的路由、WebSocket接口不做校验，SSE接口只校验请求
+func newOpenAPIValidator(renderer ErrorRenderer, opts OpenAPIOptions, mode OpenAPIValidationMode, groups ...*RouterGroup) (gin.HandlerFunc, error) {
+	// openapi3filter只支持3.0的null语义
+	opts.OpenAPIVersion = OpenAPIVersion30
+	doc, err := buildOpenAPIDoc(renderer, opts, groups...)
+	if err != nil {
+		return nil, err
+	}
+	// 生成的文档使用扩展字段引用组件，重新加载后解析为校验器可用的引用
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	doc, err = openapi3.NewLoader().LoadFromData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(c *gin.Context) {
+		route := findOpenAPIRoute(doc, c)
+		if route == nil || route.Operation.Extensions["x-websocket"] != nil {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		log := logr.FromContext(ctx)
+
+		pathParams := make(map[string]string, len(c.Params))
+		for _, param := range c.Params {
+			pathParams[param.Key] = param.Value
+		}
+		requestInput := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options: &openapi3filter.Options{
+				// 请求体不支持解码时只校验参数
+				ExcludeRequestBody:  !hasBodyDecoder(c.GetHeader("Content-Type")),
+				MultiError:          true,
+				SkipSettingDefaults: true,
+				AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+			},
+		}
+		if err := openapi3filter.ValidateRequest(ctx, requestInput); err != nil {
+			log.Warn(fmt.Errorf("request does not match openapi document: %w", err))
+			if mode == OpenAPIValidationStrict {
+				renderError(c, NewError(http.StatusBadRequest, "request does not match openapi document", err.Error()))
+				return
+			}
+		}
+
+		// SSE接口的响应是持续推送的事件流，无法缓冲后校验
+		if operationHasEventStream(route.Operation) {
+			c.Next()
+			return
+		}
+
+		// 缓冲响应，校验完成后再写入客户端
+		writer := &bufferedResponseWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = writer
+		// 处理函数panic时恢复原始的ResponseWriter，由Recovery中间件写入错误响应
+		defer func() {
+			c.Writer = writer.ResponseWriter
+		}()
+
+		c.Next()
+
+		c.Writer = writer.ResponseWriter
+		responseInput := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: requestInput,
+			Status:                 writer.status,
+			Header:                 writer.Header(),
+			Options: &openapi3filter.Options{
+				ExcludeResponseBody:   !hasBodyDecoder(writer.Header().Get("Content-Type")),
+				IncludeResponseStatus: true,
+				MultiError:            true,
+			},
+		}
+		responseInput.SetBodyBytes(writer.body.Bytes())
+		if err := openapi3filter.ValidateResponse(ctx, responseInput); err != nil {
+			log.Warn(fmt.Errorf("response does not match openapi document: %w", err))
+			if mode == OpenAPIValidationStrict {
+				writer.Header().Del("Content-Type")
+				writer.Header().Del("Content-Length")
+				renderError(c, NewError(http.StatusInternalServerError, "response does not match openapi document", err.Error()))
+				return
+			}
+		}
+		writer.flush()
+	}, nil
+}
+
+// findOpenAPIRoute 根据gin的路由查找OpenAPI文档中对应的操作，未找到时返回nil
+func findOpenAPIRoute(doc *openapi3.T, c *gin.Context) *routers.Route {
+	fullPath := c.FullPath()
+	if fullPath == "" {
+		return nil
+	}
+	apiPath := convertPathParams("/" + strings.TrimPrefix(joinURLPath(fullPath), "/"))
+	pathItem := doc.Paths.Value(apiPath)
+	if pathItem == nil {
+		return nil
+	}
+	operation := pathItem.GetOperation(c.Request.Method)
+	if operation == nil {
+		return nil
+	}
+	return &routers.Route{
+		Spec:      doc,
+		Path:      apiPath,
+		PathItem:  pathItem,
+		Method:    c.Request.Method,
+		Operation: operation,
+	}
+}
+
+// hasBodyDecoder 判断openapi3filter是否支持解码指定Content-Type的内容
+func hasBodyDecoder(contentType string) bool {
+	if contentType == "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return openapi3filter.RegisteredBodyDecoder(mediaType) != nil
+}
+
+// operationHasEventStream 判断文档中的操作是否声明了SSE响应
+func operationHasEventStream(operation *openapi3.Operation) bool {
+	if operation.Responses == nil {
+		return false
+	}
+	for _, response := range operation.Responses.Map() {
+		if response.Value != nil && response.Value.Content.Get(ContentTypeEventStream) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// bufferedResponseWriter 缓冲响应状态码和响应体，校验完成后由flush写入原始的ResponseWriter
+type bufferedResponseWriter struct {
+	gin.ResponseWriter
+	status  int
+	written bool
+	body    bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) WriteHeader(code int) {
+	if code > 0 && !w.written {
+		w.status = code
+	}
+}
+
+func (w *bufferedResponseWriter) WriteHeaderNow() {
+	w.written = true
+}
+
+func (w *bufferedResponseWriter) Write(data []byte) (int, error) {
+	w.written = true
+	return w.body.Write(data)
+}
+
+func (w *bufferedResponseWriter) WriteString(s string) (int, error) {
+	w.written = true
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedResponseWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedResponseWriter) Size() int {
+	if !w.written {
+		return -1
+	}
+	return w.body.Len()
+}
+
+func (w *bufferedResponseWriter) Written() bool {
+	return w.written
+}
+
+// Flush 缓冲期间不向客户端刷新数据
+func (w *bufferedResponseWriter) Flush() {}
+
+// flush 将缓冲的状态码和响应体写入原始的ResponseWriter
+func (w *bufferedResponseWriter) flush() {
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.body.Len() == 0 {
+		w.ResponseWriter.WriteHeaderNow()
+		return
+	}
+	_, _ = w.ResponseWriter.Write(w.body.Bytes())
+}
@@ -0,0 +1,85 @@
+package easygin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOpenAPIValidation(t *testing.T) {
+	newServer := func(mode OpenAPIValidationMode) *Server {
+		srv := NewServer("test", ":0", false).WithOpenAPIValidation(mode)
+		root := NewRouterGroup("/")
+		root.RegisterAPI(&TestClientAPI{})
+		root.RegisterAPI(&TestDriftAPI{})
+		srv.setup(root)
+		return srv
+	}
+	request := func(srv *Server, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", MIMEJSON)
+		w := httptest.NewRecorder()
+		srv.engine.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Strict", func(t *testing.T) {
+		srv := newServer(OpenAPIValidationStrict)
+
+		w := request(srv, http.MethodPost, "/items/7?tag=a", `{"name":"easygin"}`)
+		if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"name":"easygin"`) {
+			t.Fatalf("expected valid request to pass, got %d %s", w.Code, w.Body.String())
+		}
+		if w := request(srv, http.MethodPost, "/items/0", `{"name":"easygin"}`); w.Code != http.StatusNotFound {
+			t.Fatalf("expected declared error response to pass, got %d %s", w.Code, w.Body.String())
+		}
+
+		w = request(srv, http.MethodPost, "/items/7", `{"name":1}`)
+		var resp map[string]any
+		_ = json.Unmarshal(w.Body.Bytes(), &resp)
+		if w.Code != http.StatusBadRequest || resp["msg"] != "request does not match openapi document" {
+			t.Fatalf("expected request mismatch to be rejected, got %d %s", w.Code, w.Body.String())
+		}
+
+		w = request(srv, http.MethodGet, "/drift", "")
+		resp = nil
+		_ = json.Unmarshal(w.Body.Bytes(), &resp)
+		if w.Code != http.StatusInternalServerError || resp["msg"] != "response does not match openapi document" {
+			t.Fatalf("expected response mismatch to be rejected, got %d %s", w.Code, w.Body.String())
+		}
+		if desc, _ := resp["desc"].(string); !strings.Contains(desc, "id") {
+			t.Fatalf("expected mismatched field in description, got %q", desc)
+		}
+	})
+
+	t.Run("Log", func(t *testing.T) {
+		srv := newServer(OpenAPIValidationLog)
+
+		if w := request(srv, http.MethodPost, "/items/7", `{"name":1}`); w.Code != http.StatusBadRequest || strings.Contains(w.Body.String(), "openapi document") {
+			t.Fatalf("expected request to reach binder, got %d %s", w.Code, w.Body.String())
+		}
+		if w := request(srv, http.MethodGet, "/drift", ""); w.Code != http.StatusOK || w.Body.String() != `{"id":"seven"}` {
+			t.Fatalf("expected original response to be written, got %d %s", w.Code, w.Body.String())
+		}
+	})
+}
+
+// TestDriftAPI 返回值与Responses声明不一致，用于测试响应校验
+type TestDriftAPI struct {
+	MethodGet
+}
+
+func (TestDriftAPI) Path() string {
+	return "/drift"
+}
+
+func (TestDriftAPI) Responses() R {
+	return R{http.StatusOK: &TestClientResp{}}
+}
+
+func (TestDriftAPI) Output(ctx context.Context) (any, error) {
+	return map[string]any{"id": "seven"}, nil
+}
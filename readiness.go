@@ -0,0 +1,293 @@
+package easygin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultHealthCheckTimeout 默认的单项健康检查超时时间
+const DefaultHealthCheckTimeout = 3 * time.Second
+
+// DefaultReadinessCacheTTL 默认的就绪检查结果缓存时间
+const DefaultReadinessCacheTTL = time.Second
+
+// HealthChecker 定义了健康检查的接口
+// 实现此接口的类型可以注册到Readiness中，如数据库Ping、缓存连接、下游HTTP服务等
+type HealthChecker interface {
+	Name() string                    // 返回检查项名称，用于报告展示
+	Check(ctx context.Context) error // 执行检查，返回nil表示健康
+}
+
+// NewHealthChecker 使用名称和检查函数创建一个HealthChecker
+func NewHealthChecker(name string, check func(ctx context.Context) error) HealthChecker {
+	return &healthCheckFunc{name: name, check: check}
+}
+
+type healthCheckFunc struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+func (h *healthCheckFunc) Name() string {
+	return h.name
+}
+
+func (h *healthCheckFunc) Check(ctx context.Context) error {
+	return h.check(ctx)
+}
+
+// 健康状态
+const (
+	HealthStatusUp           = "up"            // 检查通过
+	HealthStatusDown         = "down"          // 检查失败
+	HealthStatusShuttingDown = "shutting_down" // 服务正在关闭
+)
+
+// HealthReport 就绪检查报告
+type HealthReport struct {
+	Status string              `json:"status" desc:"整体状态"`
+	Checks []HealthCheckResult `json:"checks" desc:"各检查项结果"`
+}
+
+// HealthCheckResult 单项健康检查结果
+type HealthCheckResult struct {
+	Name     string `json:"name" desc:"检查项名称"`
+	Status   string `json:"status" desc:"检查状态"`
+	Critical bool   `json:"critical" desc:"是否为关键检查项，关键检查项失败时服务不就绪"`
+	Duration string `json:"duration" desc:"检查耗时"`
+	Error    string `json:"error,omitempty" desc:"错误信息"`
+}
+
+// StatusCode 返回报告对应的HTTP状态码，未就绪时返回503
+func (r *HealthReport) StatusCode() int {
+	if r.Status == HealthStatusUp {
+		return http.StatusOK
+	}
+	return http.StatusServiceUnavailable
+}
+
+type registeredChecker struct {
+	checker  HealthChecker
+	critical bool
+}
+
+// Readiness 就绪检查路由
+// 并发执行所有注册的HealthChecker，任一关键检查失败时返回503
+// 服务器优雅关闭时自动切换为未就绪状态
+type Readiness struct {
+	MethodGet
+	NoOpenAPI
+	NoGenParameter
+
+	path     string
+	timeout  time.Duration
+	cacheTTL time.Duration
+	checkers []registeredChecker
+
+	shuttingDown atomic.Bool
+
+	mu       sync.Mutex
+	cached   *HealthReport
+	cachedAt time.Time
+	inflight *readinessCall // 正在执行的检查，并发的请求共享同一次检查
+}
+
+// readinessCall 一次正在执行的就绪检查
+type readinessCall struct {
+	done   chan struct{}
+	report *HealthReport
+}
+
+// NewReadinessRouter 创建一个就绪检查路由
+// 参数checkers为关键检查项，任一失败时服务不就绪
+func NewReadinessRouter(path string, checkers ...HealthChecker) *Readiness {
+	r := &Readiness{
+		path:     path,
+		timeout:  DefaultHealthCheckTimeout,
+		cacheTTL: DefaultReadinessCacheTTL,
+	}
+	for _, checker := range checkers {
+		r.Register(checker)
+	}
+	return r
+}
+
+func (r *Readiness) Path() string {
+	return r.path
+}
+
+// Register 注册关键检查项，检查失败时服务不就绪
+// 返回修改后的Readiness实例，支持链式调用
+func (r *Readiness) Register(checker HealthChecker) *Readiness {
+	r.checkers = append(r.checkers, registeredChecker{checker: checker, critical: true})
+	return r
+}
+
+// RegisterOptional 注册非关键检查项，检查失败只体现在报告中，不影响就绪状态
+// 返回修改后的Readiness实例，支持链式调用
+func (r *Readiness) RegisterOptional(checker HealthChecker) *Readiness {
+	r.checkers = append(r.checkers, registeredChecker{checker: checker, critical: false})
+	return r
+}
+
+// WithTimeout 设置单项检查的超时时间
+// 返回修改后的Readiness实例，支持链式调用
+func (r *Readiness) WithTimeout(timeout time.Duration) *Readiness {
+	r.timeout = timeout
+	return r
+}
+
+// WithCacheTTL 设置检查结果的缓存时间，小于等于0时不缓存
+// 返回修改后的Readiness实例，支持链式调用
+func (r *Readiness) WithCacheTTL(ttl time.Duration) *Readiness {
+	r.cacheTTL = ttl
+	return r
+}
+
+// markShuttingDown 将就绪状态切换为关闭中，由Server.Shutdown调用
+func (r *Readiness) markShuttingDown() {
+	r.shuttingDown.Store(true)
+}
+
+// Output 就绪检查由GinHandle处理，不会调用Output
+func (r *Readiness) Output(ctx context.Context) (any, error) {
+	return nil, nil
+}
+
+func (r *Readiness) GinHandle() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report := r.report(c.Request.Context())
+		c.JSON(report.StatusCode(), report)
+	}
+}
+
+// report 获取就绪检查报告，缓存未过期时直接返回缓存结果
+// 检查在与请求分离的上下文中执行，不持有锁，并发的请求等待同一次检查的结果
+// 请求的ctx被取消时不再等待，返回未就绪的报告，该报告不会被缓存
+func (r *Readiness) report(ctx context.Context) *HealthReport {
+	if r.shuttingDown.Load() {
+		return &HealthReport{
+			Status: HealthStatusShuttingDown,
+			Checks: []HealthCheckResult{},
+		}
+	}
+
+	r.mu.Lock()
+	if r.cached != nil && r.cacheTTL > 0 && time.Since(r.cachedAt) < r.cacheTTL {
+		report := r.cached
+		r.mu.Unlock()
+		return report
+	}
+	call := r.inflight
+	if call == nil {
+		call = &readinessCall{done: make(chan struct{})}
+		r.inflight = call
+		go r.runCall(context.WithoutCancel(ctx), call)
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.report
+	case <-ctx.Done():
+		return &HealthReport{
+			Status: HealthStatusDown,
+			Checks: []HealthCheckResult{},
+		}
+	}
+}
+
+// runCall 执行检查并更新缓存，因上下文取消导致的失败不缓存
+func (r *Readiness) runCall(ctx context.Context, call *readinessCall) {
+	report, canceled := r.runChecks(ctx)
+
+	r.mu.Lock()
+	call.report = report
+	r.inflight = nil
+	if !canceled {
+		r.cached = report
+		r.cachedAt = time.Now()
+	}
+	r.mu.Unlock()
+	close(call.done)
+}
+
+// runChecks 并发执行所有检查项，canceled表示是否有检查因上下文取消而失败
+func (r *Readiness) runChecks(ctx context.Context) (report *HealthReport, canceled bool) {
+	results := make([]HealthCheckResult, len(r.checkers))
+	errs := make([]error, len(r.checkers))
+
+	var wg sync.WaitGroup
+	for i := range r.checkers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = r.runCheck(ctx, r.checkers[i])
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if errors.Is(err, context.Canceled) {
+			canceled = true
+		}
+	}
+
+	report = &HealthReport{
+		Status: HealthStatusUp,
+		Checks: results,
+	}
+	for _, result := range results {
+		if result.Critical && result.Status != HealthStatusUp {
+			report.Status = HealthStatusDown
+			break
+		}
+	}
+	return report, canceled
+}
+
+// runCheck 执行单项检查，超时后不再等待检查函数返回，同时返回检查的错误
+func (r *Readiness) runCheck(ctx context.Context, rc registeredChecker) (HealthCheckResult, error) {
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	startAt := time.Now()
+	errCh := make(chan error, 1)
+	go func() {
+		defer func() {
+			if v := recover(); v != nil {
+				errCh <- fmt.Errorf("health check panic: %v", v)
+			}
+		}()
+		errCh <- rc.checker.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := HealthCheckResult{
+		Name:     rc.checker.Name(),
+		Status:   HealthStatusUp,
+		Critical: rc.critical,
+		Duration: time.Since(startAt).String(),
+	}
+	if err != nil {
+		result.Status = HealthStatusDown
+		result.Error = err.Error()
+	}
+	return result, err
+}
@@ -0,0 +1,128 @@
+package easygin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReadinessReport(t *testing.T) {
+	t.Run("CriticalFailure", func(t *testing.T) {
+		r := NewReadinessRouter("/readiness",
+			NewHealthChecker("db", func(ctx context.Context) error { return nil }),
+			NewHealthChecker("cache", func(ctx context.Context) error { return errors.New("connection refused") }),
+		).WithCacheTTL(0)
+
+		report := r.report(context.Background())
+		if report.Status != HealthStatusDown || report.StatusCode() != http.StatusServiceUnavailable {
+			t.Fatalf("expected down status with 503, got %+v", report)
+		}
+		if report.Checks[1].Error != "connection refused" {
+			t.Fatalf("expected cache error to be reported, got %+v", report.Checks[1])
+		}
+	})
+
+	t.Run("OptionalFailure", func(t *testing.T) {
+		r := NewReadinessRouter("/readiness").
+			RegisterOptional(NewHealthChecker("downstream", func(ctx context.Context) error { return errors.New("timeout") })).
+			WithCacheTTL(0)
+
+		report := r.report(context.Background())
+		if report.Status != HealthStatusUp || report.StatusCode() != http.StatusOK {
+			t.Fatalf("optional check failure should not affect readiness, got %+v", report)
+		}
+		if report.Checks[0].Status != HealthStatusDown {
+			t.Fatalf("expected optional check to be reported as down, got %+v", report.Checks[0])
+		}
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		r := NewReadinessRouter("/readiness",
+			NewHealthChecker("slow", func(ctx context.Context) error {
+				time.Sleep(time.Second)
+				return nil
+			}),
+		).WithTimeout(50 * time.Millisecond).WithCacheTTL(0)
+
+		startAt := time.Now()
+		report := r.report(context.Background())
+		if time.Since(startAt) > 500*time.Millisecond {
+			t.Fatalf("expected check to stop waiting after timeout")
+		}
+		if report.Status != HealthStatusDown {
+			t.Fatalf("expected timed out check to be down, got %+v", report)
+		}
+	})
+
+	t.Run("CacheAndShutdown", func(t *testing.T) {
+		var calls int32
+		r := NewReadinessRouter("/readiness",
+			NewHealthChecker("counter", func(ctx context.Context) error {
+				atomic.AddInt32(&calls, 1)
+				return nil
+			}),
+		).WithCacheTTL(time.Minute)
+
+		r.report(context.Background())
+		r.report(context.Background())
+		if n := atomic.LoadInt32(&calls); n != 1 {
+			t.Fatalf("expected cached result to be reused, got %d calls", n)
+		}
+
+		// 因上下文取消导致的失败不缓存
+		canceled := NewReadinessRouter("/readiness",
+			NewHealthChecker("canceled", func(ctx context.Context) error {
+				atomic.AddInt32(&calls, 1)
+				return context.Canceled
+			}),
+		).WithCacheTTL(time.Minute)
+		atomic.StoreInt32(&calls, 0)
+		canceled.report(context.Background())
+		canceled.report(context.Background())
+		if n := atomic.LoadInt32(&calls); n != 2 {
+			t.Fatalf("expected canceled failure not to be cached, got %d calls", n)
+		}
+
+		r.markShuttingDown()
+		report := r.report(context.Background())
+		if report.Status != HealthStatusShuttingDown || report.StatusCode() != http.StatusServiceUnavailable {
+			t.Fatalf("expected shutting down status with 503, got %+v", report)
+		}
+	})
+
+	t.Run("CanceledCaller", func(t *testing.T) {
+		var calls int32
+		r := NewReadinessRouter("/readiness",
+			NewHealthChecker("slow", func(ctx context.Context) error {
+				atomic.AddInt32(&calls, 1)
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(100 * time.Millisecond):
+					return nil
+				}
+			}),
+		).WithCacheTTL(time.Minute)
+
+		// 调用方取消后立即返回，检查在分离的上下文中继续执行
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if report := r.report(ctx); report.Status != HealthStatusDown {
+			t.Fatalf("expected down report for canceled caller, got %+v", report)
+		}
+
+		// 并发的请求共享同一次检查，且取消的请求不会导致缓存失败结果
+		if report := r.report(context.Background()); report.Status != HealthStatusUp {
+			t.Fatalf("expected check to succeed with detached context, got %+v", report)
+		}
+		if report := r.report(context.Background()); report.Status != HealthStatusUp {
+			t.Fatalf("expected cached up report, got %+v", report)
+		}
+		if n := atomic.LoadInt32(&calls); n != 1 {
+			t.Fatalf("expected a single check run, got %d", n)
+		}
+	})
+}
@@ -29,6 +29,12 @@ type RouterResponse interface {
 	Responses() R
 }
 
+// RouterExamples 定义了可以提供API响应示例的接口
+// 键为状态码，值为该状态码响应的示例，添加到OpenAPI文档中对应响应的所有媒体类型
+type RouterExamples interface {
+	Examples() map[int]any
+}
+
 // RouterAPI 定义了API路由的接口
 // 包含HTTP方法、路径和处理逻辑
 // 继承了RouterHandler接口
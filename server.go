@@ -2,12 +2,18 @@ package easygin
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/pprof"
 	"os"
+	"os/signal"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
@@ -20,12 +26,32 @@ type Server struct {
 	handlerMap       map[string]RouterAPI                      // 路由处理器映射
 	customMiddleware []gin.HandlerFunc                         // 自定义中间件列表
 	contextInjector  func(ctx context.Context) context.Context // 上下文注入函数
+	errorRenderer    ErrorRenderer                             // 错误响应渲染器
+	serveOpenAPI     bool                                      // 启动时生成OpenAPI文档并由OpenAPI路由从内存返回
+	openAPIOptions   OpenAPIOptions                            // OpenAPI文档的元数据
+	openAPIValidate  OpenAPIValidationMode                     // 根据OpenAPI文档校验请求和响应的方式
 
 	serviceName string // 服务名称，用于标识追踪器
 	addr        string // 监听地址，如":8080"
 	debug       bool   // 调试模式标志，影响日志级别和pprof启用
+
+	mu              sync.Mutex                        // 保护httpServer
+	httpServer      *http.Server                      // 底层HTTP服务器，RunContext启动后创建
+	shutdownTimeout time.Duration                     // 优雅关闭时等待在途请求完成的最长时间
+	readinessDrain  time.Duration                     // 就绪检查切换为未就绪后，停止接收新请求前的等待时间
+	onStart         []func(ctx context.Context) error // 服务启动前执行的钩子
+	onShutdown      []func(ctx context.Context) error // 服务关闭时执行的钩子
+	shutdownOnce    sync.Once                         // 确保关闭流程只执行一次
+	shutdownErr     error                             // 关闭流程的执行结果
 }
 
+// DefaultShutdownTimeout 默认的优雅关闭超时时间
+const DefaultShutdownTimeout = 10 * time.Second
+
+// DefaultReadinessDrain 默认的就绪检查排空时间
+// 负载均衡和就绪探针需要在这段时间内观察到503，将实例摘除后才停止接收新请求
+const DefaultReadinessDrain = 5 * time.Second
+
 // NewServer 创建一个新的Server实例
 //
 //	serviceName: 服务名称，用于标识追踪器
@@ -37,6 +63,9 @@ func NewServer(serviceName, addr string, debug bool) *Server {
 		addr:             addr,
 		debug:            debug,
 		customMiddleware: make([]gin.HandlerFunc, 0),
+		errorRenderer:    JSONErrorRenderer{},
+		shutdownTimeout:  DefaultShutdownTimeout,
+		readinessDrain:   DefaultReadinessDrain,
 	}
 
 	// 设置默认监听地址
@@ -76,7 +105,20 @@ func pprofRegister(e *gin.Engine) {
 // 参数groups为要注册的路由组列表
 // 如果命令行参数包含"gen"，则生成参数绑定函数后退出
 // 如果命令行参数包含"openapi"，则生成OpenAPI文档后退出
+// 如果命令行参数包含"client"，则生成Go客户端后退出，第二个参数为输出目录，默认为client
+// 如果命令行参数包含"ts"，则生成TypeScript客户端后退出，第二个参数为输出文件，默认为client.ts
+// 收到SIGINT或SIGTERM信号时，服务器会优雅关闭
 func (s *Server) Run(groups ...*RouterGroup) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return s.RunContext(ctx, groups...)
+}
+
+// RunContext 启动HTTP服务器并注册路由组，直到ctx被取消或服务器停止
+// ctx被取消后会调用Shutdown优雅关闭服务器，等待在途请求完成
+// 命令行参数"gen"、"openapi"、"client"和"ts"的处理与Run一致
+func (s *Server) RunContext(ctx context.Context, groups ...*RouterGroup) error {
 	args := os.Args
 	// 处理生成参数绑定函数的命令
 	if len(args) > 1 && args[1] == "gen" {
@@ -84,12 +126,146 @@ func (s *Server) Run(groups ...*RouterGroup) error {
 		return nil
 	}
 
-	// 处理生成OpenAPI文档的命令
+	// 处理生成OpenAPI文档的命令，错误响应格式与服务器设置的ErrorRenderer一致
 	if len(args) > 1 && args[1] == "openapi" {
-		GenerateOpenAPI(groups...)
-		return nil
+		return generateOpenAPI(s.errorRenderer, s.openAPIOptions, groups...)
+	}
+
+	// 处理生成Go客户端的命令
+	if len(args) > 1 && args[1] == "client" {
+		dir := "client"
+		if len(args) > 2 {
+			dir = args[2]
+		}
+		return GenerateClient(dir, groups...)
+	}
+
+	// 处理生成TypeScript客户端的命令，错误响应格式与服务器设置的ErrorRenderer一致
+	if len(args) > 1 && args[1] == "ts" {
+		file := "client.ts"
+		if len(args) > 2 {
+			file = args[2]
+		}
+		return generateTypeScript(s.errorRenderer, s.openAPIOptions, file, groups...)
+	}
+
+	s.setup(groups...)
+
+	httpServer := &http.Server{
+		Addr:    s.addr,
+		Handler: s.engine.Handler(),
+	}
+	s.mu.Lock()
+	s.httpServer = httpServer
+	s.mu.Unlock()
+
+	// 执行启动钩子，任一钩子失败则不启动服务器
+	for _, fn := range s.onStart {
+		if err := fn(ctx); err != nil {
+			return errors.Join(fmt.Errorf("on start hook failed: %w", err), s.Shutdown(context.Background()))
+		}
 	}
 
+	// 打印服务器启动信息
+	fmt.Printf("[EasyGin] Listening and serving HTTP on %s\n", s.addr)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			// 监听失败，执行关闭流程释放资源
+			return errors.Join(err, s.Shutdown(context.Background()))
+		}
+		// 由外部调用Shutdown关闭，等待关闭流程完成
+	case <-ctx.Done():
+		fmt.Println("[EasyGin] Shutting down server...")
+	}
+
+	return s.Shutdown(context.Background())
+}
+
+// Handler 注册中间件和路由组，返回处理请求的http.Handler，不监听端口也不执行启动钩子
+// 适用于httptest等在进程内处理请求的场景，同一个Server只能调用一次
+func (s *Server) Handler(groups ...*RouterGroup) http.Handler {
+	s.setup(groups...)
+	return s.engine.Handler()
+}
+
+// ErrorRenderer 获取错误响应渲染器
+func (s *Server) ErrorRenderer() ErrorRenderer {
+	return s.errorRenderer
+}
+
+// Shutdown 优雅关闭服务器
+// 先将注册的Readiness切换为未就绪，等待readinessDrain让负载均衡摘除实例，
+// 再停止接收新请求并等待在途请求完成，等待时间不超过shutdownTimeout
+// 随后按注册的逆序执行OnShutdown钩子，最后关闭全局TracerProvider以导出剩余的span
+// 多次调用只会执行一次关闭流程，后续调用返回相同的结果
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.shutdownOnce.Do(func() {
+		s.mu.Lock()
+		httpServer := s.httpServer
+		s.mu.Unlock()
+
+		// 将就绪检查切换为未就绪状态
+		hasReadiness := false
+		for _, api := range s.handlerMap {
+			if readiness, ok := api.(*Readiness); ok {
+				readiness.markShuttingDown()
+				hasReadiness = true
+			}
+		}
+
+		// 排空时间计入shutdownTimeout，整个关闭过程不超过设置的超时时间
+		if s.shutdownTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, s.shutdownTimeout)
+			defer cancel()
+		}
+
+		// 排空期间继续处理请求，就绪探针返回503，ctx被取消或超时时提前结束
+		if hasReadiness && httpServer != nil && s.readinessDrain > 0 {
+			timer := time.NewTimer(s.readinessDrain)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+			}
+		}
+
+		var errs []error
+
+		// 停止接收新请求，等待在途请求完成
+		if httpServer != nil {
+			if err := httpServer.Shutdown(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("shutdown http server failed: %w", err))
+			}
+		}
+
+		// 逆序执行关闭钩子，先启动的资源后释放
+		for i := len(s.onShutdown) - 1; i >= 0; i-- {
+			if err := s.onShutdown[i](ctx); err != nil {
+				errs = append(errs, fmt.Errorf("on shutdown hook failed: %w", err))
+			}
+		}
+
+		// 关闭全局TracerProvider，确保最后的span被导出
+		if err := shutdownGlobalTracerProvider(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("shutdown tracer provider failed: %w", err))
+		}
+
+		s.shutdownErr = errors.Join(errs...)
+	})
+
+	return s.shutdownErr
+}
+
+// setup 初始化中间件并注册所有路由组
+func (s *Server) setup(groups ...*RouterGroup) {
 	// 调试模式下注册pprof路由
 	if s.debug {
 		// 添加pprof接口
@@ -99,6 +275,26 @@ func (s *Server) Run(groups ...*RouterGroup) error {
 	// 初始化路由处理器映射
 	s.handlerMap = make(map[string]RouterAPI)
 
+	// 将错误渲染器添加到上下文中，API、中间件、404和panic恢复使用同一种错误格式
+	s.engine.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(ContextWithErrorRenderer(c.Request.Context(), s.errorRenderer))
+	})
+
+	// 根据注册的路由组生成OpenAPI文档，OpenAPI路由从上下文中获取文档
+	if s.serveOpenAPI {
+		doc, err := buildOpenAPIDoc(s.errorRenderer, s.openAPIOptions, groups...)
+		if err != nil {
+			panic(fmt.Sprintf("generate openapi document failed: %v", err))
+		}
+		document, err := newOpenAPIDocument(doc)
+		if err != nil {
+			panic(fmt.Sprintf("encode openapi document failed: %v", err))
+		}
+		s.engine.Use(func(c *gin.Context) {
+			c.Request = c.Request.WithContext(contextWithOpenAPIDocument(c.Request.Context(), document))
+		})
+	}
+
 	// 添加OpenTelemetry中间件
 	s.engine.Use(otelgin.Middleware(s.serviceName))
 
@@ -107,12 +303,7 @@ func (s *Server) Run(groups ...*RouterGroup) error {
 
 	// 添加404处理
 	s.engine.NoRoute(func(c *gin.Context) {
-		resp := &gin.H{
-			"code": http.StatusNotFound,
-			"msg":  "404 page not found",
-			"desc": "404 page not found",
-		}
-		c.AbortWithStatusJSON(http.StatusNotFound, resp)
+		renderError(c, NewError(http.StatusNotFound, "404 page not found", "404 page not found"))
 	})
 
 	// 添加请求主路由到上下文中
@@ -138,14 +329,18 @@ func (s *Server) Run(groups ...*RouterGroup) error {
 
 		_ = c.Error(e)
 
-		resp := &gin.H{
-			"code": http.StatusInternalServerError,
-			"msg":  http.StatusText(http.StatusInternalServerError),
-			"desc": e.Error(),
-		}
-		c.AbortWithStatusJSON(http.StatusInternalServerError, resp)
+		renderError(c, NewError(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), e.Error()).WithError(e))
 	}))
 
+	// 根据OpenAPI文档校验请求和响应，位于自定义中间件之前，中间件返回的错误响应同样会被校验
+	if s.openAPIValidate != OpenAPIValidationOff {
+		validator, err := newOpenAPIValidator(s.errorRenderer, s.openAPIOptions, s.openAPIValidate, groups...)
+		if err != nil {
+			panic(fmt.Sprintf("load openapi document for validation failed: %v", err))
+		}
+		s.engine.Use(validator)
+	}
+
 	// 注册自定义的中间件
 	if len(s.customMiddleware) > 0 {
 		s.engine.Use(s.customMiddleware...)
@@ -160,7 +355,7 @@ func (s *Server) Run(groups ...*RouterGroup) error {
 
 	// 注册所有路由组
 	for _, group := range groups {
-		handleGroup(s.handlerMap, &s.engine.RouterGroup, group)
+		handleGroup(s.handlerMap, &s.engine.RouterGroup, group, middlewareChain{})
 	}
 
 	// 打印JSON请求体验证和默认值设置的状态提示
@@ -181,36 +376,26 @@ func (s *Server) Run(groups ...*RouterGroup) error {
 	if s.debug {
 		gin.SetMode(gin.DebugMode)
 	}
-
-	if !s.debug {
-		// 打印服务器启动信息
-		fmt.Printf("[EasyGin] Listening and serving HTTP on %s\n", s.addr)
-	}
-
-	// 启动HTTP服务器
-	return s.engine.Run(s.addr)
 }
 
 // handleGroup 递归处理路由组，注册中间件和API
 // 参数:
 //   - e: 父路由组
 //   - group: 要处理的路由组
-//   - parentMiddlewareNames: 父路由组的中间件名称列表
-func handleGroup(handlerMap map[string]RouterAPI, e *gin.RouterGroup, group *RouterGroup, parentMiddlewareNames ...string) {
+//   - parent: 父路由组的中间件链
+func handleGroup(handlerMap map[string]RouterAPI, e *gin.RouterGroup, group *RouterGroup, parent middlewareChain) {
 	// 创建当前路由组
 	g := e.Group(group.path)
 	basePath := g.BasePath()
 
-	middlewareNames := make([]string, 0, len(parentMiddlewareNames)+len(group.middlewares))
-
-	// 添加父路由组的中间件名称
-	middlewareNames = append(middlewareNames, parentMiddlewareNames...)
+	// 复制父路由组的中间件链，避免兄弟路由组之间互相影响
+	chain := parent.clone(len(group.middlewares))
 
 	// 注册中间件
 	for _, handler := range group.middlewares {
 		// 获取处理器名称
 		handlerName := getHandlerName(handler)
-		middlewareNames = append(middlewareNames, handlerName)
+		chain.add(handler, handlerName)
 
 		if ginHandler, ok := handler.(GinHandler); ok {
 			// 处理实现了GinHandler接口的中间件
@@ -246,11 +431,18 @@ func handleGroup(handlerMap map[string]RouterAPI, e *gin.RouterGroup, group *Rou
 		// 获取API描述
 		description := getHandlerDescription(handler)
 
-		// 打印路由信息
+		// 打印路由信息，WebSocket API打印为WS
+		shortMethod := getShortMethod(method)
+		if _, ok := handler.(WebSocketHandler); ok {
+			shortMethod = "WS"
+		}
 		if description != "" {
-			fmt.Printf("[EasyGin] %s %s %s\n", getShortMethod(method), routePath, description)
+			fmt.Printf("[EasyGin] %s %s %s\n", shortMethod, routePath, description)
 		} else {
-			fmt.Printf("[EasyGin] %s %s\n", getShortMethod(method), routePath)
+			fmt.Printf("[EasyGin] %s %s\n", shortMethod, routePath)
+		}
+		if isHandlerDeprecated(handler) {
+			fmt.Printf("[EasyGin]     WARNING: %s %s is deprecated\n", shortMethod, routePath)
 		}
 
 		// 收集路由信息
@@ -260,9 +452,18 @@ func handleGroup(handlerMap map[string]RouterAPI, e *gin.RouterGroup, group *Rou
 		}
 		handlerMap[fmt.Sprintf("%s %s", method, routePath)] = handler
 
+		// 检查Dep字段声明的依赖都由中间件链提供
+		if err := validateDependencies(handler, chain.outputs); err != nil {
+			panic(fmt.Sprintf("%s %s: %s", method, routePath, err))
+		}
+		// 检查枚举声明能否合并为enum校验规则
+		if err := validateEnumTags(reflect.TypeOf(handlerValue(handler))); err != nil {
+			panic(fmt.Sprintf("%s %s: %s", method, routePath, err))
+		}
+
 		// 打印中间件和处理器
-		if len(middlewareNames) > 0 {
-			fmt.Printf("[EasyGin]     %s %s\n", strings.Join(middlewareNames, " "), handlerName)
+		if len(chain.names) > 0 {
+			fmt.Printf("[EasyGin]     %s %s\n", strings.Join(chain.names, " "), handlerName)
 		} else {
 			fmt.Printf("[EasyGin]     %s\n", handlerName)
 		}
@@ -280,6 +481,15 @@ func handleGroup(handlerMap map[string]RouterAPI, e *gin.RouterGroup, group *Rou
 			continue
 		}
 
+		// 处理实现了WebSocketHandler接口的API
+		if _, ok := handler.(WebSocketHandler); ok {
+			if !isWebSocketMethod(handler) {
+				panic(fmt.Sprintf("websocket api %s must use MethodWebSocket", handlerName))
+			}
+			g.GET(handler.Path(), renderWebSocket(handler, handlerName))
+			continue
+		}
+
 		// 处理实现了RouterHandler接口的API
 		if handler.Method() == "ANY" {
 			g.Any(handler.Path(), renderAPI(handler, handlerName))
@@ -288,10 +498,45 @@ func handleGroup(handlerMap map[string]RouterAPI, e *gin.RouterGroup, group *Rou
 		g.Handle(handler.Method(), handler.Path(), renderAPI(handler, handlerName))
 	}
 
-	// 递归处理子路由组，传递当前路由组的中间件名称
+	// 递归处理子路由组，传递当前路由组的中间件链
 	for _, sub := range group.children {
-		handleGroup(handlerMap, g, sub, middlewareNames...)
+		handleGroup(handlerMap, g, sub, chain)
+	}
+}
+
+// middlewareChain 注册路由时记录的中间件链
+type middlewareChain struct {
+	names   []string                // 中间件名称列表
+	outputs map[reflect.Type]string // NewMiddleware注册的输出类型到中间件名称的映射
+}
+
+// clone 复制中间件链，n为预计追加的中间件数量
+func (chain middlewareChain) clone(n int) middlewareChain {
+	names := make([]string, 0, len(chain.names)+n)
+	names = append(names, chain.names...)
+	outputs := make(map[reflect.Type]string, len(chain.outputs))
+	for t, name := range chain.outputs {
+		outputs[t] = name
+	}
+	return middlewareChain{names: names, outputs: outputs}
+}
+
+// add 追加中间件，检查中间件的依赖并记录NewMiddleware注册的输出类型
+func (chain *middlewareChain) add(handler RouterHandler, handlerName string) {
+	if err := validateDependencies(handler, chain.outputs); err != nil {
+		panic(fmt.Sprintf("middleware %s: %s", handlerName, err))
 	}
+	if err := validateEnumTags(reflect.TypeOf(handlerValue(handler))); err != nil {
+		panic(fmt.Sprintf("middleware %s: %s", handlerName, err))
+	}
+	if wrapper, ok := handler.(middlewareWrapper); ok {
+		t := wrapper.outputType()
+		if name, exists := chain.outputs[t]; exists {
+			panic(fmt.Sprintf("middleware %s outputs %s, which is already provided by %s", handlerName, t, name))
+		}
+		chain.outputs[t] = handlerName
+	}
+	chain.names = append(chain.names, handlerName)
 }
 
 // getShortMethod 获取HTTP方法的简短表示
@@ -305,8 +550,8 @@ func getShortMethod(method string) string {
 
 // getHandlerName 获取处理器的名称
 func getHandlerName(handler RouterHandler) string {
-	// 使用反射获取
-	t := reflect.TypeOf(handler)
+	// 使用反射获取，NewMiddleware创建的中间件使用内部中间件的名称
+	t := reflect.TypeOf(handlerValue(handler))
 	if t.Kind() == reflect.Ptr {
 		t = t.Elem()
 	}
@@ -317,8 +562,26 @@ func getHandlerName(handler RouterHandler) string {
 
 // getHandlerDescription 获取处理器的描述信息
 func getHandlerDescription(handler RouterHandler) string {
-	// 尝试从结构体标签中获取summary
-	t := reflect.TypeOf(handler)
+	summary, _ := getMethodFieldTag(handler, "summary")
+	return summary
+}
+
+// getHandlerDetail 获取处理器的详细说明，对应Method字段的description标签，支持Markdown
+func getHandlerDetail(handler RouterHandler) string {
+	description, _ := getMethodFieldTag(handler, "description")
+	return description
+}
+
+// isHandlerDeprecated 判断处理器是否已废弃，对应Method字段的deprecated标签
+func isHandlerDeprecated(handler RouterHandler) bool {
+	value, _ := getMethodFieldTag(handler, "deprecated")
+	deprecated, _ := strconv.ParseBool(value)
+	return deprecated
+}
+
+// getMethodFieldTag 获取处理器中Method字段（如MethodGet）上的标签
+func getMethodFieldTag(handler RouterHandler, key string) (string, bool) {
+	t := reflect.TypeOf(handlerValue(handler))
 	if t.Kind() == reflect.Ptr {
 		t = t.Elem()
 	}
@@ -327,27 +590,28 @@ func getHandlerDescription(handler RouterHandler) string {
 		// 遍历结构体字段
 		for i := 0; i < t.NumField(); i++ {
 			field := t.Field(i)
-			fieldType := field.Type
-
-			// 检查字段类型是否实现了Method() string接口
-			methodType, ok := fieldType.MethodByName("Method")
-			if !ok {
-				continue
-			}
-
-			// 验证Method方法的签名是否为 Method() string
-			if methodType.Type.NumIn() != 1 || methodType.Type.NumOut() != 1 || methodType.Type.Out(0).Kind() != reflect.String {
+			if !isMethodField(field) {
 				continue
 			}
 
-			// 检查字段是否有summary标签
-			if summary, ok := field.Tag.Lookup("summary"); ok {
-				return summary
+			// 检查字段是否有对应的标签
+			if value, ok := field.Tag.Lookup(key); ok {
+				return value, true
 			}
 		}
 	}
 
-	return ""
+	return "", false
+}
+
+// isMethodField 判断字段是否为Method字段，即字段类型实现了Method() string接口
+func isMethodField(field reflect.StructField) bool {
+	methodType, ok := field.Type.MethodByName("Method")
+	if !ok {
+		return false
+	}
+	// 验证Method方法的签名是否为 Method() string
+	return methodType.Type.NumIn() == 1 && methodType.Type.NumOut() == 1 && methodType.Type.Out(0).Kind() == reflect.String
 }
 
 // WithGinMiddleware 添加全局Gin中间件
@@ -364,6 +628,46 @@ func (s *Server) WithContextInjector(withContext WithContext) *Server {
 	return s
 }
 
+// WithErrorRenderer 设置错误响应渲染器，默认为JSONErrorRenderer
+// API和中间件返回的错误、404和panic恢复都使用该渲染器，生成的OpenAPI文档也使用对应的错误响应格式
+// 返回修改后的Server实例，支持链式调用
+func (s *Server) WithErrorRenderer(renderer ErrorRenderer) *Server {
+	if renderer == nil {
+		renderer = JSONErrorRenderer{}
+	}
+	s.errorRenderer = renderer
+	return s
+}
+
+// WithOpenAPI 启动时根据注册的路由组生成OpenAPI文档并保存在内存中
+// 启用后OpenAPIRouter直接返回内存中的文档，不再读取当前目录下的openapi.json，
+// 错误响应格式与WithErrorRenderer设置的渲染器一致
+// 返回修改后的Server实例，支持链式调用
+func (s *Server) WithOpenAPI() *Server {
+	s.serveOpenAPI = true
+	return s
+}
+
+// WithOpenAPIValidation 启动时根据注册的路由组生成OpenAPI文档，并使用文档校验每个请求和响应
+// 用于发现Responses()声明与Output实际返回值之间的差异，校验需要缓冲响应并解析请求体，建议只在开发和测试环境启用
+//
+//	OpenAPIValidationLog: 不一致时记录警告日志
+//	OpenAPIValidationStrict: 请求不一致时返回400，响应不一致时返回500
+//
+// 返回修改后的Server实例，支持链式调用
+func (s *Server) WithOpenAPIValidation(mode OpenAPIValidationMode) *Server {
+	s.openAPIValidate = mode
+	return s
+}
+
+// WithOpenAPIOptions 设置OpenAPI文档的标题、版本、服务地址和安全认证方式等元数据
+// 对openapi命令、ts命令和WithOpenAPI生成的文档都生效
+// 返回修改后的Server实例，支持链式调用
+func (s *Server) WithOpenAPIOptions(opts OpenAPIOptions) *Server {
+	s.openAPIOptions = opts
+	return s
+}
+
 // WithContext 定义了上下文注入函数类型
 // 接收一个上下文并返回修改后的上下文
 type WithContext = func(ctx context.Context) context.Context
@@ -379,3 +683,36 @@ func WithContextCompose(withContexts ...WithContext) WithContext {
 		return ctx
 	}
 }
+
+// WithShutdownTimeout 设置优雅关闭时等待在途请求完成的最长时间，包括就绪检查的排空时间
+// 参数timeout小于等于0时不限制等待时间
+// 返回修改后的Server实例，支持链式调用
+func (s *Server) WithShutdownTimeout(timeout time.Duration) *Server {
+	s.shutdownTimeout = timeout
+	return s
+}
+
+// WithReadinessDrain 设置优雅关闭时就绪检查切换为未就绪后，停止接收新请求前的等待时间，默认为5秒
+// 等待期间服务器继续处理请求，就绪探针返回503，负载均衡有时间将实例摘除
+// 只在注册了Readiness时生效，排空时间计入WithShutdownTimeout设置的超时时间，参数drain小于等于0时不等待
+// 返回修改后的Server实例，支持链式调用
+func (s *Server) WithReadinessDrain(drain time.Duration) *Server {
+	s.readinessDrain = drain
+	return s
+}
+
+// OnStart 添加服务启动钩子，在开始监听前按注册顺序执行
+// 任一钩子返回错误时，服务器不会启动
+// 返回修改后的Server实例，支持链式调用
+func (s *Server) OnStart(fn func(ctx context.Context) error) *Server {
+	s.onStart = append(s.onStart, fn)
+	return s
+}
+
+// OnShutdown 添加服务关闭钩子，在在途请求处理完成后按注册的逆序执行
+// 适用于关闭数据库连接池、刷新缓冲区等资源释放操作
+// 返回修改后的Server实例，支持链式调用
+func (s *Server) OnShutdown(fn func(ctx context.Context) error) *Server {
+	s.onShutdown = append(s.onShutdown, fn)
+	return s
+}
@@ -0,0 +1,197 @@
+package easygin
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestServerRunContextGracefulShutdown(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen failed: %v", err)
+	}
+	addr := listener.Addr().String()
+	_ = listener.Close()
+
+	started := make(chan struct{})
+	var calls []string
+
+	srv := NewServer("test", addr, false).
+		WithShutdownTimeout(5 * time.Second).
+		OnStart(func(ctx context.Context) error {
+			calls = append(calls, "start")
+			close(started)
+			return nil
+		}).
+		OnShutdown(func(ctx context.Context) error {
+			calls = append(calls, "shutdown-1")
+			return nil
+		}).
+		OnShutdown(func(ctx context.Context) error {
+			calls = append(calls, "shutdown-2")
+			return nil
+		})
+
+	root := NewRouterGroup("/")
+	root.RegisterAPI(&testSlowAPI{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.RunContext(ctx, root)
+	}()
+
+	<-started
+
+	// 等待服务器开始监听后发起一个慢请求，并在请求处理中触发关闭
+	respCh := make(chan *http.Response, 1)
+	go func() {
+		for i := 0; i < 50; i++ {
+			resp, err := http.Get("http://" + addr + "/slow")
+			if err == nil {
+				respCh <- resp
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		respCh <- nil
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	resp := <-respCh
+	if resp == nil {
+		t.Fatal("request to slow API failed")
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected in-flight request to complete with 200, got %d", resp.StatusCode)
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("RunContext returned error: %v", err)
+	}
+
+	expected := []string{"start", "shutdown-2", "shutdown-1"}
+	if len(calls) != len(expected) {
+		t.Fatalf("expected hook calls %v, got %v", expected, calls)
+	}
+	for i := range expected {
+		if calls[i] != expected[i] {
+			t.Fatalf("expected hook calls %v, got %v", expected, calls)
+		}
+	}
+}
+
+func TestServerShutdownReadinessDrain(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen failed: %v", err)
+	}
+	addr := listener.Addr().String()
+	_ = listener.Close()
+
+	started := make(chan struct{})
+	srv := NewServer("test", addr, false).
+		WithReadinessDrain(500 * time.Millisecond).
+		OnStart(func(ctx context.Context) error {
+			close(started)
+			return nil
+		})
+	root := NewRouterGroup("/")
+	root.RegisterAPI(NewReadinessRouter("/readiness").WithCacheTTL(0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.RunContext(ctx, root)
+	}()
+	<-started
+
+	get := func() (int, error) {
+		resp, err := http.Get("http://" + addr + "/readiness")
+		if err != nil {
+			return 0, err
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+	for i := 0; i < 50; i++ {
+		if code, err := get(); err == nil && code == http.StatusOK {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	time.Sleep(100 * time.Millisecond)
+
+	// 排空期间服务器仍然接收请求，就绪探针返回503
+	if code, err := get(); err != nil || code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 during readiness drain, got %d %v", code, err)
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("RunContext returned error: %v", err)
+	}
+	if _, err := get(); err == nil {
+		t.Fatal("expected server to stop accepting requests after drain")
+	}
+}
+
+func TestServerShutdownDrainWithinTimeout(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen failed: %v", err)
+	}
+	addr := listener.Addr().String()
+	_ = listener.Close()
+
+	started := make(chan struct{})
+	srv := NewServer("test", addr, false).
+		WithShutdownTimeout(200 * time.Millisecond).
+		WithReadinessDrain(5 * time.Second).
+		OnStart(func(ctx context.Context) error {
+			close(started)
+			return nil
+		})
+	root := NewRouterGroup("/")
+	root.RegisterAPI(NewReadinessRouter("/readiness"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.RunContext(ctx, root)
+	}()
+	<-started
+	time.Sleep(50 * time.Millisecond)
+
+	// 排空时间计入关闭超时，整个关闭过程不超过WithShutdownTimeout
+	begin := time.Now()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("shutdown exceeded the shutdown timeout")
+	}
+	if elapsed := time.Since(begin); elapsed > time.Second {
+		t.Fatalf("expected shutdown within timeout, took %s", elapsed)
+	}
+}
+
+type testSlowAPI struct {
+	MethodGet
+}
+
+func (testSlowAPI) Path() string {
+	return "/slow"
+}
+
+func (testSlowAPI) Output(ctx context.Context) (any, error) {
+	time.Sleep(300 * time.Millisecond)
+	return "ok", nil
+}
@@ -0,0 +1,173 @@
+package easygin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContentTypeEventStream Server-Sent Events的Content-Type
+const ContentTypeEventStream = "text/event-stream"
+
+// DefaultEventStreamHeartbeat 默认的心跳间隔
+const DefaultEventStreamHeartbeat = 15 * time.Second
+
+// Event Server-Sent Events事件
+type Event struct {
+	ID    string        // 事件ID，客户端重连时通过Last-Event-ID请求头带回
+	Event string        // 事件类型，为空时客户端按message事件处理
+	Data  any           // 事件数据，string和[]byte原样写入，其他类型编码为JSON
+	Retry time.Duration // 客户端重连间隔，为0时不发送
+}
+
+// EventStream 以text/event-stream格式输出的事件流
+// Output返回EventStream后，事件会在写入后立即刷新到客户端，客户端断开连接时停止输出
+// 作为Responses()中的响应模型时，Model用于生成事件数据的OpenAPI文档
+type EventStream struct {
+	Events    <-chan Event  // 事件通道，关闭通道表示事件流结束
+	Heartbeat time.Duration // 心跳间隔，为0时使用DefaultEventStreamHeartbeat，小于0时不发送心跳
+	Model     any           // 事件数据模型，仅用于生成OpenAPI文档
+}
+
+// NewEventStream 使用事件通道创建事件流
+func NewEventStream(events <-chan Event) *EventStream {
+	return &EventStream{Events: events}
+}
+
+// NewEventStreamFromSeq 使用迭代器创建事件流
+// 迭代器使用ctx控制生命周期，客户端断开连接后yield返回false，迭代器应当停止产生事件
+func NewEventStreamFromSeq(ctx context.Context, seq iter.Seq[Event]) *EventStream {
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+		for event := range seq {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return &EventStream{Events: events}
+}
+
+// WithHeartbeat 设置心跳间隔，小于0时不发送心跳
+// 返回修改后的EventStream实例，支持链式调用
+func (s *EventStream) WithHeartbeat(interval time.Duration) *EventStream {
+	s.Heartbeat = interval
+	return s
+}
+
+// WithModel 设置事件数据模型，用于生成OpenAPI文档
+// 返回修改后的EventStream实例，支持链式调用
+func (s *EventStream) WithModel(model any) *EventStream {
+	s.Model = model
+	return s
+}
+
+// eventStreamStats 事件流的输出统计，用于访问日志
+type eventStreamStats struct {
+	startedAt time.Time // 响应头刷新到客户端的时间
+	events    int       // 已输出的事件数量
+}
+
+// renderEventStream 输出事件流，直到事件通道关闭或客户端断开连接
+func renderEventStream(c *gin.Context, code int, stream *EventStream) {
+	ctx := c.Request.Context()
+
+	stats := &eventStreamStats{}
+	c.Request = c.Request.WithContext(contextWithEventStreamStats(ctx, stats))
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", ContentTypeEventStream)
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	// 关闭nginx等反向代理的响应缓冲
+	header.Set("X-Accel-Buffering", "no")
+	c.Status(code)
+	c.Writer.Flush()
+	stats.startedAt = time.Now()
+
+	heartbeat := stream.Heartbeat
+	if heartbeat == 0 {
+		heartbeat = DefaultEventStreamHeartbeat
+	}
+	var ticks <-chan time.Time
+	if heartbeat > 0 {
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			// 客户端断开连接
+			return
+		case event, ok := <-stream.Events:
+			if !ok {
+				return
+			}
+			if err := writeEvent(c.Writer, event); err != nil {
+				_ = c.Error(err)
+				return
+			}
+			stats.events++
+		case <-ticks:
+			// 注释行用于保持连接，客户端会忽略
+			if _, err := io.WriteString(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+// writeEvent 按照text/event-stream格式写入一个事件
+func writeEvent(w io.Writer, event Event) error {
+	var data string
+	switch v := event.Data.(type) {
+	case nil:
+	case string:
+		data = v
+	case []byte:
+		data = string(v)
+	default:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("encode event data failed: %w", err)
+		}
+		data = string(raw)
+	}
+
+	var builder strings.Builder
+	if event.ID != "" {
+		builder.WriteString("id: " + sanitizeEventField(event.ID) + "\n")
+	}
+	if event.Event != "" {
+		builder.WriteString("event: " + sanitizeEventField(event.Event) + "\n")
+	}
+	if event.Retry > 0 {
+		builder.WriteString("retry: " + strconv.FormatInt(event.Retry.Milliseconds(), 10) + "\n")
+	}
+	// 多行数据按行拆分为多个data字段
+	for _, line := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		builder.WriteString("data: " + line + "\n")
+	}
+	builder.WriteString("\n")
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+// sanitizeEventField 去掉单行字段中的换行符，避免破坏事件格式
+func sanitizeEventField(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
@@ -0,0 +1,168 @@
+package easygin
+
+import (
+	"bufio"
+	"context"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+)
+
+func TestWriteEvent(t *testing.T) {
+	var builder strings.Builder
+	err := writeEvent(&builder, Event{
+		ID:    "1",
+		Event: "update\nforged",
+		Data:  "line1\nline2",
+		Retry: 3 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("writeEvent returned error: %v", err)
+	}
+	expected := "id: 1\nevent: updateforged\nretry: 3000\ndata: line1\ndata: line2\n\n"
+	if builder.String() != expected {
+		t.Fatalf("unexpected event:\n%q\nexpected:\n%q", builder.String(), expected)
+	}
+
+	builder.Reset()
+	if err := writeEvent(&builder, Event{Data: map[string]int{"count": 1}}); err != nil {
+		t.Fatalf("writeEvent returned error: %v", err)
+	}
+	if builder.String() != "data: {\"count\":1}\n\n" {
+		t.Fatalf("expected JSON encoded data, got %q", builder.String())
+	}
+}
+
+func TestRenderEventStream(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var stats *eventStreamStats
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		c.Next()
+		stats = eventStreamStatsFromContext(c.Request.Context())
+	})
+	engine.GET("/events", renderAPI(&testEventStreamAPI{}, "testEventStreamAPI"))
+
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/events?count=3")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != ContentTypeEventStream {
+		t.Fatalf("expected %s, got %s", ContentTypeEventStream, ct)
+	}
+
+	body := readAll(t, bufio.NewReader(resp.Body))
+	if strings.Count(body, "event: tick\n") != 3 || !strings.Contains(body, "data: {\"index\":2}\n") {
+		t.Fatalf("unexpected event stream:\n%s", body)
+	}
+	if !strings.Contains(body, ": heartbeat\n\n") {
+		t.Fatalf("expected heartbeat comment, got:\n%s", body)
+	}
+	if stats == nil || stats.events != 3 || stats.startedAt.IsZero() {
+		t.Fatalf("expected event stream stats, got %+v", stats)
+	}
+}
+
+func TestRenderEventStreamClientDisconnect(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testEventStreamStopped = make(chan struct{})
+	defer func() { testEventStreamStopped = nil }()
+
+	engine := gin.New()
+	engine.GET("/events", renderAPI(&testEventStreamAPI{}, "testEventStreamAPI"))
+
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/events", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+
+	// 读到第一个事件后断开连接
+	reader := bufio.NewReader(resp.Body)
+	if line, err := reader.ReadString('\n'); err != nil || !strings.HasPrefix(line, "event: tick") {
+		t.Fatalf("expected first event, got %q (%v)", line, err)
+	}
+	cancel()
+	resp.Body.Close()
+
+	select {
+	case <-testEventStreamStopped:
+	case <-time.After(time.Second):
+		t.Fatal("expected event iterator to stop after client disconnect")
+	}
+}
+
+func TestEventStreamOpenAPIResponse(t *testing.T) {
+	doc := &openapi3.T{Components: &openapi3.Components{Schemas: openapi3.Schemas{}}}
+
+	content := responseContent(doc, &testEventStreamAPI{}, NewEventStream(nil).WithModel(&testTick{}))
+	media := content.Get(ContentTypeEventStream)
+	if media == nil || len(content) != 1 {
+		t.Fatalf("expected only %s content, got %v", ContentTypeEventStream, content)
+	}
+	if ref, _ := media.Schema.Value.Extensions["$ref"].(string); ref != "#/components/schemas/GithubComZboycoEasyginTestTick" {
+		t.Fatalf("expected event data schema reference, got %q", ref)
+	}
+}
+
+func readAll(t *testing.T, reader *bufio.Reader) string {
+	t.Helper()
+	var builder strings.Builder
+	for {
+		line, err := reader.ReadString('\n')
+		builder.WriteString(line)
+		if err != nil {
+			return builder.String()
+		}
+	}
+}
+
+type testTick struct {
+	Index int `json:"index"`
+}
+
+// testEventStreamStopped 事件迭代器结束时关闭，用于检查客户端断开连接后迭代器是否停止
+var testEventStreamStopped chan struct{}
+
+// testEventStreamAPI Count大于0时输出Count个事件后结束，否则持续输出直到客户端断开连接
+type testEventStreamAPI struct {
+	MethodGet
+	Count int `in:"query" name:"count,omitempty"`
+}
+
+func (testEventStreamAPI) Path() string {
+	return "/events"
+}
+
+func (api *testEventStreamAPI) Output(ctx context.Context) (any, error) {
+	stopped := testEventStreamStopped
+	seq := iter.Seq[Event](func(yield func(Event) bool) {
+		if stopped != nil {
+			defer close(stopped)
+		}
+		for i := 0; api.Count == 0 || i < api.Count; i++ {
+			if !yield(Event{Event: "tick", Data: testTick{Index: i}}) {
+				return
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+	})
+	return NewEventStreamFromSeq(ctx, seq).WithHeartbeat(10 * time.Millisecond), nil
+}
@@ -0,0 +1,520 @@
+package easygin
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// 字段错误原因
+const (
+	reasonMissingParameter = "missing required parameter"
+	reasonMissingField     = "missing required field"
+	reasonMissingFile      = "missing required file"
+	reasonInvalidValue     = "invalid value"
+)
+
+// FieldError 单个字段的校验错误
+type FieldError struct {
+	In     string `json:"in" desc:"参数位置，如path、query、header、cookie、body、form"`
+	Name   string `json:"name" desc:"参数名称，请求体中的嵌套字段使用.连接"`
+	Reason string `json:"reason" desc:"错误原因"`
+	Value  string `json:"value,omitempty" desc:"请求中的原始值"`
+}
+
+// ValidationError 参数校验错误，包含所有绑定或校验失败的字段
+// 实现了ErrorHttp接口，状态码固定为400
+type ValidationError struct {
+	Errors []FieldError
+}
+
+// ValidationErrorResponse 参数校验错误的响应体
+// 同时用于生成OpenAPI文档中的400响应
+type ValidationErrorResponse struct {
+	Code   int          `json:"code" desc:"状态码"`
+	Msg    string       `json:"msg" desc:"错误信息"`
+	Desc   string       `json:"desc" desc:"错误描述"`
+	Errors []FieldError `json:"errors" desc:"绑定或校验失败的字段列表"`
+}
+
+// fieldErrorValue 获取字段错误中返回的原始值
+// header和cookie中常见Authorization、会话ID等凭证，不在错误响应中回显
+func fieldErrorValue(in, value string) string {
+	if in == "header" || in == "cookie" {
+		return ""
+	}
+	return value
+}
+
+// Add 添加一个字段错误
+func (e *ValidationError) Add(in, name, reason, value string) {
+	e.Errors = append(e.Errors, FieldError{In: in, Name: name, Reason: reason, Value: value})
+}
+
+// Err 存在字段错误时返回自身，否则返回nil
+func (e *ValidationError) Err() error {
+	if e == nil || len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Collect 收集err中的字段错误
+// err为ValidationError时合并其字段错误并返回nil，否则原样返回err
+func (e *ValidationError) Collect(err error) error {
+	if err == nil {
+		return nil
+	}
+	var other *ValidationError
+	if !errors.As(err, &other) {
+		return err
+	}
+	e.Errors = append(e.Errors, other.Errors...)
+	return nil
+}
+
+// Response 返回用于序列化的响应体
+func (e *ValidationError) Response() *ValidationErrorResponse {
+	return &ValidationErrorResponse{
+		Code:   e.StatusCode(),
+		Msg:    e.Error(),
+		Desc:   e.Desc(),
+		Errors: e.Errors,
+	}
+}
+
+func (e *ValidationError) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Errors))
+	for _, fe := range e.Errors {
+		if fe.Name == "" {
+			parts = append(parts, fmt.Sprintf("%s: %s", fe.In, fe.Reason))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s '%s': %s", fe.In, fe.Name, fe.Reason))
+		}
+	}
+	return "invalid parameters: " + strings.Join(parts, "; ")
+}
+
+func (e *ValidationError) Desc() string {
+	return "invalid parameters"
+}
+
+// validateRules 解析后的validate标签规则
+//
+//	validate:"min=1,max=100"
+//	validate:"len=6,pattern=^[0-9]+$"
+//	validate:"enum=asc|desc"
+//	validate:"email"
+//
+// min/max 对数值类型表示取值范围，对字符串、切片和map表示长度范围
+// pattern 必须是最后一条规则，其后的所有内容（包括逗号）都属于正则表达式
+type validateRules struct {
+	min     *float64
+	max     *float64
+	length  *int
+	pattern *regexp.Regexp
+	enum    []string
+	format  string
+}
+
+// validateRulesCache 缓存解析后的validate标签规则，key为标签内容
+var validateRulesCache sync.Map
+
+// 支持的字符串格式
+var validateFormats = map[string]func(string) bool{
+	"email": isEmail,
+	"uuid":  isUUID,
+}
+
+// getValidateRules 获取并缓存validate标签规则
+func getValidateRules(tag string) (*validateRules, error) {
+	if cached, ok := validateRulesCache.Load(tag); ok {
+		return cached.(*validateRules), nil
+	}
+
+	rules, err := parseValidateRules(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	validateRulesCache.Store(tag, rules)
+	return rules, nil
+}
+
+// parseValidateRules 解析validate标签
+func parseValidateRules(tag string) (*validateRules, error) {
+	rules := &validateRules{}
+	rest := tag
+	for rest != "" {
+		// pattern 规则取剩余全部内容，允许正则表达式中包含逗号
+		if strings.HasPrefix(rest, "pattern=") {
+			re, err := regexp.Compile(strings.TrimPrefix(rest, "pattern="))
+			if err != nil {
+				return nil, fmt.Errorf("invalid validate tag '%s': %v", tag, err)
+			}
+			rules.pattern = re
+			break
+		}
+
+		rule := rest
+		if i := strings.Index(rest, ","); i >= 0 {
+			rule, rest = rest[:i], rest[i+1:]
+		} else {
+			rest = ""
+		}
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "min", "max":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid validate tag '%s': %s requires a number", tag, key)
+			}
+			if key == "min" {
+				rules.min = &n
+			} else {
+				rules.max = &n
+			}
+		case "len":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid validate tag '%s': len requires a non-negative integer", tag)
+			}
+			rules.length = &n
+		case "enum":
+			if value == "" {
+				return nil, fmt.Errorf("invalid validate tag '%s': enum requires values", tag)
+			}
+			rules.enum = strings.Split(value, "|")
+		case "format":
+			if _, ok := validateFormats[value]; !ok {
+				return nil, fmt.Errorf("invalid validate tag '%s': unsupported format '%s'", tag, value)
+			}
+			rules.format = value
+		default:
+			if _, ok := validateFormats[key]; ok && value == "" {
+				rules.format = key
+				continue
+			}
+			return nil, fmt.Errorf("invalid validate tag '%s': unknown rule '%s'", tag, key)
+		}
+	}
+	return rules, nil
+}
+
+// ValidateValue 按照validate标签规则校验值，返回第一个不满足的规则
+// 指针为nil时不做校验，必填校验由omitempty处理
+func ValidateValue(v any, tag string) error {
+	if tag == "" {
+		return nil
+	}
+	rules, err := getValidateRules(tag)
+	if err != nil {
+		return err
+	}
+	if reason := rules.validate(reflect.ValueOf(v)); reason != "" {
+		return errors.New(reason)
+	}
+	return nil
+}
+
+// validate 校验值，返回错误原因，校验通过时返回空字符串
+func (r *validateRules) validate(v reflect.Value) string {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return ""
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		if reason := r.validateLength(utf8.RuneCountInString(v.String())); reason != "" {
+			return reason
+		}
+		return r.validateString(v.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if reason := r.validateRange(float64(v.Int())); reason != "" {
+			return reason
+		}
+		return r.validateEnum(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if reason := r.validateRange(float64(v.Uint())); reason != "" {
+			return reason
+		}
+		return r.validateEnum(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		if reason := r.validateRange(v.Float()); reason != "" {
+			return reason
+		}
+		return r.validateEnum(strconv.FormatFloat(v.Float(), 'f', -1, 64))
+	case reflect.Bool:
+		return r.validateEnum(strconv.FormatBool(v.Bool()))
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if reason := r.validateLength(v.Len()); reason != "" {
+			return reason
+		}
+		if v.Kind() == reflect.Map || (r.pattern == nil && len(r.enum) == 0 && r.format == "") {
+			return ""
+		}
+		// 元素逐个校验字符串规则和枚举
+		elemRules := &validateRules{pattern: r.pattern, enum: r.enum, format: r.format}
+		for i := 0; i < v.Len(); i++ {
+			if reason := elemRules.validate(v.Index(i)); reason != "" {
+				return fmt.Sprintf("item %d %s", i, reason)
+			}
+		}
+	}
+	return ""
+}
+
+func (r *validateRules) validateRange(n float64) string {
+	if r.min != nil && n < *r.min {
+		return "must be at least " + formatRuleNumber(*r.min)
+	}
+	if r.max != nil && n > *r.max {
+		return "must be at most " + formatRuleNumber(*r.max)
+	}
+	return ""
+}
+
+func (r *validateRules) validateLength(n int) string {
+	if r.length != nil && n != *r.length {
+		return fmt.Sprintf("length must be exactly %d", *r.length)
+	}
+	if r.min != nil && float64(n) < *r.min {
+		return "length must be at least " + formatRuleNumber(*r.min)
+	}
+	if r.max != nil && float64(n) > *r.max {
+		return "length must be at most " + formatRuleNumber(*r.max)
+	}
+	return ""
+}
+
+func (r *validateRules) validateString(s string) string {
+	if r.pattern != nil && !r.pattern.MatchString(s) {
+		return fmt.Sprintf("must match pattern '%s'", r.pattern.String())
+	}
+	if r.format != "" && !validateFormats[r.format](s) {
+		return "must be a valid " + r.format
+	}
+	return r.validateEnum(s)
+}
+
+func (r *validateRules) validateEnum(s string) string {
+	if len(r.enum) == 0 {
+		return ""
+	}
+	for _, e := range r.enum {
+		if e == s {
+			return ""
+		}
+	}
+	return fmt.Sprintf("must be one of [%s]", strings.Join(r.enum, ", "))
+}
+
+func formatRuleNumber(n float64) string {
+	if n == math.Trunc(n) {
+		return strconv.FormatInt(int64(n), 10)
+	}
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+func isEmail(s string) bool {
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Address == s
+}
+
+var uuidRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+func isUUID(s string) bool {
+	return uuidRegexp.MatchString(s)
+}
+
+// jsonValidateField 存储JSON请求体字段的校验信息
+type jsonValidateField struct {
+	index     int
+	name      string
+	anonymous bool
+	omitempty bool
+	rules     *validateRules
+}
+
+// jsonValidateFieldsCache 用于缓存结构体字段的校验信息
+var jsonValidateFieldsCache sync.Map
+
+// getJsonValidateFields 获取并缓存结构体中需要校验或递归处理的字段
+func getJsonValidateFields(t reflect.Type) ([]jsonValidateField, error) {
+	if cached, ok := jsonValidateFieldsCache.Load(t); ok {
+		return cached.([]jsonValidateField), nil
+	}
+
+	fields := make([]jsonValidateField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		tagParts := strings.Split(field.Tag.Get("json"), ",")
+		if tagParts[0] == "-" {
+			continue
+		}
+
+		info := jsonValidateField{
+			index:     i,
+			name:      tagParts[0],
+			anonymous: field.Anonymous && tagParts[0] == "",
+			omitempty: slices.Contains(tagParts[1:], "omitempty"),
+		}
+		if info.name == "" {
+			info.name = field.Name
+		}
+
+		if tag := validateTag(field); tag != "" {
+			rules, err := getValidateRules(tag)
+			if err != nil {
+				return nil, err
+			}
+			info.rules = rules
+		}
+
+		if info.rules == nil && !typeHasValidateRules(field.Type) {
+			continue
+		}
+		fields = append(fields, info)
+	}
+
+	jsonValidateFieldsCache.Store(t, fields)
+	return fields, nil
+}
+
+// ValidateJsonFields 递归校验JSON请求体中带有validate标签的字段，校验错误添加到verrs中
+func ValidateJsonFields(v reflect.Value, verrs *ValidationError) error {
+	return validateJsonFields(v, "", verrs)
+}
+
+func validateJsonFields(v reflect.Value, prefix string, verrs *ValidationError) error {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := validateJsonFields(v.Index(i), fmt.Sprintf("%s[%d]", prefix, i), verrs); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := validateJsonFields(iter.Value(), fmt.Sprintf("%s[%v]", prefix, iter.Key().Interface()), verrs); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Struct:
+	default:
+		return nil
+	}
+
+	fields, err := getJsonValidateFields(v.Type())
+	if err != nil {
+		return err
+	}
+
+	for _, info := range fields {
+		fieldValue := v.Field(info.index)
+
+		name := info.name
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		if info.anonymous {
+			name = prefix
+		}
+
+		if info.rules != nil && !(info.omitempty && IsZeroValue(fieldValue)) {
+			if reason := info.rules.validate(fieldValue); reason != "" {
+				verrs.Add("body", name, reason, formatFieldValue(fieldValue))
+				continue
+			}
+		}
+
+		if err := validateJsonFields(fieldValue, name, verrs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatFieldValue 将字段值格式化为字符串，用于字段错误中的value
+func formatFieldValue(v reflect.Value) string {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() || !v.CanInterface() {
+		return ""
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// typeHasValidateRulesCache 缓存类型中是否包含validate标签
+var typeHasValidateRulesCache sync.Map
+
+// typeHasValidateRules 判断类型（包括嵌套的结构体、切片元素）中是否包含validate标签
+func typeHasValidateRules(t reflect.Type) bool {
+	if cached, ok := typeHasValidateRulesCache.Load(t); ok {
+		return cached.(bool)
+	}
+	result := typeHasValidateRulesVisited(t, map[reflect.Type]bool{})
+	typeHasValidateRulesCache.Store(t, result)
+	return result
+}
+
+func typeHasValidateRulesVisited(t reflect.Type, visited map[reflect.Type]bool) bool {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	// 已访问过的类型直接跳过，避免循环引用导致无限递归
+	if t.Kind() != reflect.Struct || visited[t] {
+		return false
+	}
+	visited[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if validateTag(field) != "" || typeHasValidateRulesVisited(field.Type, visited) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package easygin
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestValidateValue(t *testing.T) {
+	cases := []struct {
+		value  any
+		tag    string
+		reason string
+	}{
+		{5, "min=1,max=10", ""},
+		{0, "min=1,max=10", "must be at least 1"},
+		{11.5, "max=10", "must be at most 10"},
+		{"abc", "len=3", ""},
+		{"abcd", "min=1,max=3", "length must be at most 3"},
+		{"a,b", "pattern=^[a-z],[a-z]$", ""},
+		{"123", "pattern=^[a-z]+$", "must match pattern '^[a-z]+$'"},
+		{"asc", "enum=asc|desc", ""},
+		{2, "enum=1|3", "must be one of [1, 3]"},
+		{"user@example.com", "email", ""},
+		{"user", "format=email", "must be a valid email"},
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", "uuid", ""},
+		{[]string{"a", "b"}, "max=1", "length must be at most 1"},
+		{[]string{"asc", "up"}, "enum=asc|desc", "item 1 must be one of [asc, desc]"},
+		{(*int)(nil), "min=1", ""},
+	}
+
+	for _, c := range cases {
+		err := ValidateValue(c.value, c.tag)
+		reason := ""
+		if err != nil {
+			reason = err.Error()
+		}
+		if reason != c.reason {
+			t.Fatalf("ValidateValue(%v, %q) = %q, expected %q", c.value, c.tag, reason, c.reason)
+		}
+	}
+
+	for _, tag := range []string{"min=a", "len=-1", "enum=", "format=ipv4", "unknown=1", "pattern=["} {
+		if _, err := parseValidateRules(tag); err == nil {
+			t.Fatalf("expected invalid validate tag %q to fail", tag)
+		}
+	}
+}
+
+func TestApplyValidateRules(t *testing.T) {
+	doc := &openapi3.T{Components: &openapi3.Components{Schemas: openapi3.Schemas{}}}
+
+	intSchema := generateSchema(doc, reflect.TypeOf(0), false)
+	applyValidateRules(intSchema, "min=1,max=100")
+	if *intSchema.Min != 1 || *intSchema.Max != 100 {
+		t.Fatalf("expected minimum/maximum, got %+v", intSchema)
+	}
+
+	strSchema := generateSchema(doc, reflect.TypeOf(""), false)
+	applyValidateRules(strSchema, "min=2,max=8,pattern=^[a-z]+$")
+	if strSchema.MinLength != 2 || *strSchema.MaxLength != 8 || strSchema.Pattern != "^[a-z]+$" {
+		t.Fatalf("expected string length and pattern, got %+v", strSchema)
+	}
+
+	arrSchema := generateSchema(doc, reflect.TypeOf([]int{}), false)
+	applyValidateRules(arrSchema, "max=3,enum=1|2")
+	if *arrSchema.MaxItems != 3 {
+		t.Fatalf("expected maxItems 3, got %+v", arrSchema)
+	}
+	if !reflect.DeepEqual(arrSchema.Items.Value.Enum, []any{int64(1), int64(2)}) {
+		t.Fatalf("expected integer enum on items, got %+v", arrSchema.Items.Value.Enum)
+	}
+}
+
+func TestValidationErrorOpenAPIResponse(t *testing.T) {
+	doc := &openapi3.T{
+		Paths:      openapi3.NewPaths(),
+		Components: &openapi3.Components{Schemas: openapi3.Schemas{}},
+	}
+
+	group := NewRouterGroup("/")
+	group.RegisterAPI(&TestBindingErrorsHandler{})
+	if err := generateGroupPaths(doc, JSONErrorRenderer{}, group, "", nil); err != nil {
+		t.Fatalf("generateGroupPaths returned error: %v", err)
+	}
+
+	var op *openapi3.Operation
+	for _, item := range doc.Paths.Map() {
+		op = item.Post
+	}
+	if op == nil {
+		t.Fatal("expected operation to be generated")
+	}
+	resp := op.Responses.Value("400")
+	if resp == nil {
+		t.Fatal("expected 400 response for operation with parameters")
+	}
+	ref, _ := resp.Value.Content["application/json"].Schema.Value.Extensions["$ref"].(string)
+	if !strings.HasSuffix(ref, "ValidationErrorResponse") {
+		t.Fatalf("expected 400 response to reference ValidationErrorResponse schema, got %q", ref)
+	}
+}
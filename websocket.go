@@ -0,0 +1,176 @@
+package easygin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zboyco/easygin/logr"
+	"golang.org/x/net/websocket"
+)
+
+// WebSocketHandler 定义了WebSocket连接的处理接口
+// API嵌入MethodWebSocket并实现此接口后，query、header、cookie等参数在升级连接之前完成绑定，
+// 参数错误按照普通请求返回，升级成功后每个连接调用一次ServeWebSocket
+type WebSocketHandler interface {
+	ServeWebSocket(ctx context.Context, conn WebSocketConn) error
+}
+
+// WebSocketMessageType WebSocket消息的类型
+type WebSocketMessageType int
+
+const (
+	WebSocketTextMessage   WebSocketMessageType = websocket.TextFrame   // 文本消息
+	WebSocketBinaryMessage WebSocketMessageType = websocket.BinaryFrame // 二进制消息
+)
+
+// WebSocketConn 定义了WebSocket连接的读写接口，屏蔽底层WebSocket库的实现
+// 每次ReadMessage读取一条完整的消息，客户端断开连接时返回错误
+type WebSocketConn interface {
+	ReadMessage() (WebSocketMessageType, []byte, error)
+	WriteMessage(messageType WebSocketMessageType, data []byte) error
+	Close() error
+}
+
+// webSocketMessage 单条WebSocket消息
+type webSocketMessage struct {
+	messageType WebSocketMessageType
+	data        []byte
+}
+
+// webSocketMessageCodec 按照消息类型收发WebSocket消息
+var webSocketMessageCodec = websocket.Codec{
+	Marshal: func(v any) ([]byte, byte, error) {
+		msg := v.(webSocketMessage)
+		return msg.data, byte(msg.messageType), nil
+	},
+	Unmarshal: func(data []byte, payloadType byte, v any) error {
+		msg := v.(*webSocketMessage)
+		msg.messageType = WebSocketMessageType(payloadType)
+		msg.data = data
+		return nil
+	},
+}
+
+// webSocketConn 基于golang.org/x/net/websocket实现WebSocketConn
+type webSocketConn struct {
+	conn *websocket.Conn
+}
+
+func (c *webSocketConn) ReadMessage() (WebSocketMessageType, []byte, error) {
+	var msg webSocketMessage
+	if err := webSocketMessageCodec.Receive(c.conn, &msg); err != nil {
+		return 0, nil, err
+	}
+	return msg.messageType, msg.data, nil
+}
+
+func (c *webSocketConn) WriteMessage(messageType WebSocketMessageType, data []byte) error {
+	if messageType != WebSocketTextMessage && messageType != WebSocketBinaryMessage {
+		return fmt.Errorf("unsupported websocket message type %d", messageType)
+	}
+	return webSocketMessageCodec.Send(c.conn, webSocketMessage{messageType: messageType, data: data})
+}
+
+func (c *webSocketConn) Close() error {
+	return c.conn.Close()
+}
+
+// WebSocketOriginChecker 定义了WebSocket握手时校验Origin的接口
+// 未实现此接口时，只允许未携带Origin或者Origin与请求Host相同的连接
+type WebSocketOriginChecker interface {
+	CheckOrigin(r *http.Request) bool
+}
+
+// errWebSocketOutput MethodWebSocket提供的Output不会被调用，WebSocket API必须实现WebSocketHandler
+var errWebSocketOutput = errors.New("websocket api must implement WebSocketHandler")
+
+// renderWebSocket 处理WebSocket API
+// 先绑定参数，再升级连接，连接建立后在独立的span中调用ServeWebSocket
+func renderWebSocket(h RouterHandler, handlerName string) gin.HandlerFunc {
+	if _, ok := h.(WebSocketHandler); !ok {
+		panic(fmt.Sprintf("%s: %v", handlerName, errWebSocketOutput))
+	}
+
+	return func(c *gin.Context) {
+		// 将handlerName存入context
+		c.Request = c.Request.WithContext(ContextWithHandlerName(c.Request.Context(), handlerName))
+
+		newHandler, err := bindHandler(c, h)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+		wsHandler := newHandler.(WebSocketHandler)
+
+		server := websocket.Server{
+			Handshake: func(config *websocket.Config, r *http.Request) error {
+				if checker, ok := newHandler.(WebSocketOriginChecker); ok {
+					if !checker.CheckOrigin(r) {
+						return fmt.Errorf("origin not allowed")
+					}
+				} else if !isSameOrigin(r) {
+					return fmt.Errorf("origin not allowed")
+				}
+				config.Origin, _ = websocket.Origin(config, r)
+				return nil
+			},
+			Handler: func(conn *websocket.Conn) {
+				// 连接已经升级，访问日志中记录101状态码
+				c.Status(http.StatusSwitchingProtocols)
+
+				ctx, log := logr.Start(ContextWithGinContext(c.Request.Context(), c), "WebSocket "+handlerName)
+				defer log.End()
+
+				if err := wsHandler.ServeWebSocket(ctx, &webSocketConn{conn: conn}); err != nil {
+					log.Error(err)
+					_ = c.Error(err)
+				}
+			},
+		}
+		server.ServeHTTP(c.Writer, c.Request)
+	}
+}
+
+// isWebSocketMethod 判断API是否嵌入了MethodWebSocket
+func isWebSocketMethod(h RouterHandler) bool {
+	t := reflect.TypeOf(handlerValue(h))
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	methodWebSocketType := reflect.TypeOf(MethodWebSocket{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !isMethodField(field) {
+			continue
+		}
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType == methodWebSocketType {
+			return true
+		}
+	}
+	return false
+}
+
+// isSameOrigin 判断请求是否未携带Origin或者Origin与请求Host相同
+func isSameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return u.Host == r.Host
+}
@@ -0,0 +1,149 @@
+package easygin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"golang.org/x/net/websocket"
+)
+
+func TestWebSocketHandler(t *testing.T) {
+	srv := NewServer("test", ":0", false)
+	root := NewRouterGroup("/")
+	root.RegisterAPI(&testWebSocketAPI{})
+	srv.setup(root)
+
+	ts := httptest.NewServer(srv.engine)
+	defer ts.Close()
+	wsURL := "ws" + strings.TrimPrefix(ts.URL, "http")
+
+	t.Run("Echo", func(t *testing.T) {
+		conn, err := websocket.Dial(wsURL+"/echo?prefix=re:", "", ts.URL)
+		if err != nil {
+			t.Fatalf("dial failed: %v", err)
+		}
+		defer conn.Close()
+
+		if err := websocket.Message.Send(conn, "hello"); err != nil {
+			t.Fatalf("send failed: %v", err)
+		}
+		var reply string
+		if err := websocket.Message.Receive(conn, &reply); err != nil {
+			t.Fatalf("receive failed: %v", err)
+		}
+		if reply != "re:hello" {
+			t.Fatalf("expected bound prefix in reply, got %q", reply)
+		}
+	})
+
+	t.Run("BinaryMessage", func(t *testing.T) {
+		conn, err := websocket.Dial(wsURL+"/echo?prefix=re:", "", ts.URL)
+		if err != nil {
+			t.Fatalf("dial failed: %v", err)
+		}
+		defer conn.Close()
+
+		if err := websocket.Message.Send(conn, []byte{0x01, 0x02}); err != nil {
+			t.Fatalf("send failed: %v", err)
+		}
+		var reply []byte
+		if err := websocket.Message.Receive(conn, &reply); err != nil {
+			t.Fatalf("receive failed: %v", err)
+		}
+		if string(reply) != "re:\x01\x02" {
+			t.Fatalf("expected binary reply, got %q", reply)
+		}
+	})
+
+	t.Run("BindingErrorBeforeUpgrade", func(t *testing.T) {
+		resp, err := http.Get(ts.URL + "/echo")
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("expected 400 for missing query parameter, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("CrossOrigin", func(t *testing.T) {
+		if _, err := websocket.Dial(wsURL+"/echo?prefix=re:", "", "http://evil.example.com"); err == nil {
+			t.Fatal("expected cross origin handshake to fail")
+		}
+	})
+}
+
+func TestWebSocketRequiresMethodWebSocket(t *testing.T) {
+	root := NewRouterGroup("/")
+	root.RegisterAPI(&testWebSocketGetAPI{})
+	assertSetupPanics(t, root, "must use MethodWebSocket")
+}
+
+func TestWebSocketOpenAPI(t *testing.T) {
+	doc := &openapi3.T{
+		Paths:      openapi3.NewPaths(),
+		Components: &openapi3.Components{Schemas: openapi3.Schemas{}},
+	}
+
+	root := NewRouterGroup("/")
+	root.RegisterAPI(&testWebSocketAPI{})
+	if err := generateGroupPaths(doc, JSONErrorRenderer{}, root, "", nil); err != nil {
+		t.Fatalf("generateGroupPaths returned error: %v", err)
+	}
+
+	op := doc.Paths.Value("/echo").Get
+	if op == nil {
+		t.Fatal("expected websocket api to be documented as GET")
+	}
+	if op.Extensions["x-websocket"] != true {
+		t.Fatalf("expected x-websocket extension, got %v", op.Extensions)
+	}
+	if op.Responses.Value("101") == nil || op.Responses.Value("200") != nil {
+		t.Fatalf("expected 101 response instead of 200, got %v", op.Responses.Map())
+	}
+	if len(op.Parameters) != 1 || op.Parameters[0].Value.Name != "prefix" {
+		t.Fatalf("expected bound query parameter, got %v", op.Parameters)
+	}
+}
+
+type testWebSocketAPI struct {
+	MethodWebSocket `summary:"回显"`
+	Prefix          string `in:"query" name:"prefix"`
+}
+
+func (testWebSocketAPI) Path() string {
+	return "/echo"
+}
+
+func (api *testWebSocketAPI) ServeWebSocket(ctx context.Context, conn WebSocketConn) error {
+	for {
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			return nil
+		}
+		if err := conn.WriteMessage(messageType, append([]byte(api.Prefix), data...)); err != nil {
+			return err
+		}
+	}
+}
+
+// testWebSocketGetAPI 实现了WebSocketHandler但没有嵌入MethodWebSocket
+type testWebSocketGetAPI struct {
+	MethodGet
+}
+
+func (testWebSocketGetAPI) Path() string {
+	return "/echo"
+}
+
+func (api *testWebSocketGetAPI) Output(ctx context.Context) (any, error) {
+	return nil, nil
+}
+
+func (api *testWebSocketGetAPI) ServeWebSocket(ctx context.Context, conn WebSocketConn) error {
+	return nil
+}